@@ -0,0 +1,150 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// bicepValue is a node in the small Bicep value AST used by the emitter's object/array
+// block renderers (ports, env, volumes, and the like), replacing hand-rolled
+// fmt.Sprintf/bytes.Buffer string building with a pretty-printer over a typed tree.
+type bicepValue interface {
+	// print renders the value as Bicep source. baseIndent is the indentation, in spaces,
+	// of the line the value starts on; nested objects indent their fields two spaces
+	// deeper than baseIndent.
+	print(baseIndent int) string
+}
+
+// bicepLiteral is a single-quoted string literal, e.g. 'value'.
+type bicepLiteral string
+
+func (v bicepLiteral) print(int) string {
+	return fmt.Sprintf("'%s'", string(v))
+}
+
+// bicepRaw is an unquoted Bicep expression, e.g. secretstore.id.
+type bicepRaw string
+
+func (v bicepRaw) print(int) string {
+	return string(v)
+}
+
+// bicepNumber is a bare integer literal.
+type bicepNumber int
+
+func (v bicepNumber) print(int) string {
+	return fmt.Sprintf("%d", int(v))
+}
+
+// bicepBool is a bare boolean literal.
+type bicepBool bool
+
+func (v bicepBool) print(int) string {
+	return fmt.Sprintf("%t", bool(v))
+}
+
+// bicepField is one key/value pair of a bicepObject, in emission order.
+type bicepField struct {
+	Key   string
+	Value bicepValue
+}
+
+// bicepObject is an ordered `{ key: value }` object. An empty object prints as "{}".
+type bicepObject struct {
+	Fields []bicepField
+}
+
+func (o bicepObject) print(baseIndent int) string {
+	if len(o.Fields) == 0 {
+		return "{}"
+	}
+
+	fieldIndent := strings.Repeat(" ", baseIndent+2)
+
+	var buf bytes.Buffer
+	buf.WriteString("{\n")
+
+	for _, field := range o.Fields {
+		buf.WriteString(fieldIndent)
+		buf.WriteString(field.Key)
+		buf.WriteString(": ")
+		buf.WriteString(field.Value.print(baseIndent + 2))
+		buf.WriteString("\n")
+	}
+
+	buf.WriteString(strings.Repeat(" ", baseIndent))
+	buf.WriteString("}")
+
+	return buf.String()
+}
+
+// bicepArray is a `[item, item]` array, always rendered inline on one line.
+type bicepArray struct {
+	Items []bicepValue
+}
+
+func (a bicepArray) print(int) string {
+	parts := make([]string, len(a.Items))
+	for i, item := range a.Items {
+		parts[i] = item.print(0)
+	}
+
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// bicepObjectArray is a `[ {...}, {...} ]` array of objects, always rendered one object
+// per line (unlike bicepArray, which is always inline).
+type bicepObjectArray struct {
+	Items []bicepObject
+}
+
+func (a bicepObjectArray) print(baseIndent int) string {
+	if len(a.Items) == 0 {
+		return "[]"
+	}
+
+	itemIndent := baseIndent + 2
+	indent := strings.Repeat(" ", itemIndent)
+
+	var buf bytes.Buffer
+	buf.WriteString("[")
+
+	for _, item := range a.Items {
+		buf.WriteString("\n")
+		buf.WriteString(indent)
+		buf.WriteString(item.print(itemIndent))
+	}
+
+	buf.WriteString("\n")
+	buf.WriteString(strings.Repeat(" ", baseIndent))
+	buf.WriteString("]")
+
+	return buf.String()
+}
+
+// bicepLiteralArray builds a bicepArray of quoted string literals.
+func bicepLiteralArray(items []string) bicepArray {
+	values := make([]bicepValue, len(items))
+	for i, item := range items {
+		values[i] = bicepLiteral(item)
+	}
+
+	return bicepArray{Items: values}
+}
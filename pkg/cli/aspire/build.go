@@ -0,0 +1,243 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// defaultBuilderImage is the Cloud Native Buildpacks builder used when BuilderImage is unset.
+const defaultBuilderImage = "paketobuildpacks/builder-jammy-base"
+
+// packBuild invokes the `pack` CLI to build sourcePath into imageRef using Cloud Native
+// Buildpacks. It is a package-level variable so tests can substitute a fake builder.
+var packBuild = runPackBuild
+
+// dotnetPublish invokes `dotnet publish /t:PublishContainer` to build sourcePath into
+// imageRef. It is a package-level variable so tests can substitute a fake builder.
+var dotnetPublish = runDotnetPublish
+
+// dockerBuild invokes `docker build` to build sourcePath (a directory containing a
+// Dockerfile) into imageRef. It is a package-level variable so tests can substitute a
+// fake builder.
+var dockerBuild = runDockerBuild
+
+// dockerPush invokes `docker push` to push imageRef to its registry. It is a package-level
+// variable so tests can substitute a fake pusher.
+var dockerPush = runDockerPush
+
+// buildProjectImage produces an image reference for a project.v0/v1 resource that has no
+// entry in ImageMappings, according to the configured BuildMode. For BuildModeBuildpacks,
+// it builds the image locally and returns its tag. For BuildModeTektonPipeline, it returns
+// the tag the generated pipeline will push to without building anything locally.
+func buildProjectImage(name string, resource ManifestResource, cfg *translationConfig) (string, error) {
+	imageRef := fmt.Sprintf("%s:latest", name)
+	if cfg.registry != "" {
+		imageRef = fmt.Sprintf("%s/%s:latest", strings.TrimSuffix(cfg.registry, "/"), name)
+	}
+
+	switch cfg.buildMode {
+	case BuildModeBuildpacks:
+		if resource.Path == "" {
+			return "", &missingBuildSourceError{resourceName: name}
+		}
+
+		if err := packBuild(resource.Path, imageRef, cfg.builderImage); err != nil {
+			return "", err
+		}
+
+		if err := pushBuiltImage(imageRef, cfg); err != nil {
+			return "", err
+		}
+
+		return imageRef, nil
+
+	case BuildModeTektonPipeline:
+		if resource.Path == "" {
+			return "", &missingBuildSourceError{resourceName: name}
+		}
+
+		// The pipeline builds and pushes the image; Bicep only references where it will land.
+		return imageRef, nil
+
+	case BuildModeDotnetPublish:
+		if resource.Path == "" {
+			return "", &missingBuildSourceError{resourceName: name}
+		}
+
+		if err := dotnetPublish(resource.Path, imageRef); err != nil {
+			return "", err
+		}
+
+		if err := pushBuiltImage(imageRef, cfg); err != nil {
+			return "", err
+		}
+
+		return imageRef, nil
+
+	case BuildModeDockerfile:
+		if resource.Path == "" {
+			return "", &missingBuildSourceError{resourceName: name}
+		}
+
+		if err := dockerBuild(resource.Path, imageRef); err != nil {
+			return "", err
+		}
+
+		if err := pushBuiltImage(imageRef, cfg); err != nil {
+			return "", err
+		}
+
+		return imageRef, nil
+
+	case BuildModePlaceholderParam:
+		return sanitize(name) + "Image", nil
+
+	default:
+		return "", &missingImageMappingError{resourceName: name}
+	}
+}
+
+// pushBuiltImage pushes imageRef to its registry when cfg.pushImages is set. A no-op when
+// pushImages is false or no registry was configured (nothing to push to).
+func pushBuiltImage(imageRef string, cfg *translationConfig) error {
+	if !cfg.pushImages || cfg.registry == "" {
+		return nil
+	}
+
+	return dockerPush(imageRef)
+}
+
+// buildCompanionImage looks for a sibling manifest resource marked BuildOnly with
+// Parent == name (see KindBuildOutput), returning the image it supplies, if any. This
+// lets a co-located resource produced by an external build step (e.g. an azd-style
+// ".buildOnly" companion) wire its image into a project.v0/v1 resource without the
+// caller having to pass an explicit ImageMappings entry.
+func buildCompanionImage(ctx *translationContext, name string) (string, bool) {
+	if ctx.manifest == nil {
+		return "", false
+	}
+
+	for _, resource := range ctx.manifest.Resources {
+		if resource.BuildOnly && resource.Parent == name && resource.Image != "" {
+			return resource.Image, true
+		}
+	}
+
+	return "", false
+}
+
+// runPackBuild shells out to the `pack` CLI to build sourcePath into imageRef.
+func runPackBuild(sourcePath, imageRef, builderImage string) error {
+	if builderImage == "" {
+		builderImage = defaultBuilderImage
+	}
+
+	cmd := exec.Command("pack", "build", imageRef, "--path", sourcePath, "--builder", builderImage)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("buildpacks build failed for %q: %w", sourcePath, err)
+	}
+
+	return nil
+}
+
+// runDotnetPublish shells out to `dotnet publish` with the .NET SDK's PublishContainer
+// target to build sourcePath (a .csproj file or its containing directory) into imageRef.
+func runDotnetPublish(sourcePath, imageRef string) error {
+	repository, tag := splitImageRef(imageRef)
+
+	cmd := exec.Command("dotnet", "publish", sourcePath,
+		"/t:PublishContainer",
+		fmt.Sprintf("/p:ContainerRepository=%s", repository),
+		fmt.Sprintf("/p:ContainerImageTag=%s", tag),
+	)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("dotnet publish failed for %q: %w", sourcePath, err)
+	}
+
+	return nil
+}
+
+// runDockerBuild shells out to `docker build` to build sourcePath (a directory containing a
+// Dockerfile) into imageRef.
+func runDockerBuild(sourcePath, imageRef string) error {
+	cmd := exec.Command("docker", "build", "-t", imageRef, sourcePath)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("docker build failed for %q: %w", sourcePath, err)
+	}
+
+	return nil
+}
+
+// runDockerPush shells out to `docker push` to push imageRef to its registry.
+func runDockerPush(imageRef string) error {
+	cmd := exec.Command("docker", "push", imageRef)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("docker push failed for %q: %w", imageRef, err)
+	}
+
+	return nil
+}
+
+// splitImageRef splits a "repository:tag" image reference into its two parts, defaulting
+// the tag to "latest" when imageRef has none.
+func splitImageRef(imageRef string) (repository, tag string) {
+	idx := strings.LastIndex(imageRef, ":")
+	if idx < 0 || idx < strings.LastIndex(imageRef, "/") {
+		// No colon, or only a registry port such as "localhost:5000/api".
+		return imageRef, "latest"
+	}
+
+	return imageRef[:idx], imageRef[idx+1:]
+}
+
+// LoadImageMappings reads a JSON file mapping project resource names to container image
+// references (the same shape as TranslateOptions.ImageMappings) for use with the
+// --image-map CLI flag.
+func LoadImageMappings(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("image map file not found: %s", path)
+		}
+
+		return nil, fmt.Errorf("failed to read image map file: %w", err)
+	}
+
+	var mappings map[string]string
+	if err := json.Unmarshal(data, &mappings); err != nil {
+		return nil, fmt.Errorf("failed to parse image map file: %w", err)
+	}
+
+	return mappings, nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadImageMappings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("parses a valid image map file", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "image-map.json")
+		content := `{"api": "myregistry.io/api:v1", "worker": "myregistry.io/worker:v1"}`
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write image map file: %v", err)
+		}
+
+		mappings, err := LoadImageMappings(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if mappings["api"] != "myregistry.io/api:v1" {
+			t.Errorf("expected api mapping, got %q", mappings["api"])
+		}
+
+		if mappings["worker"] != "myregistry.io/worker:v1" {
+			t.Errorf("expected worker mapping, got %q", mappings["worker"])
+		}
+	})
+
+	t.Run("errors on missing file", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := LoadImageMappings(filepath.Join(t.TempDir(), "missing.json"))
+		if err == nil {
+			t.Fatal("expected error for missing file")
+		}
+	})
+
+	t.Run("errors on invalid JSON", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "image-map.json")
+		if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+			t.Fatalf("failed to write image map file: %v", err)
+		}
+
+		_, err := LoadImageMappings(path)
+		if err == nil {
+			t.Fatal("expected error for invalid JSON")
+		}
+	})
+}
+
+func TestSplitImageRef(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		imageRef       string
+		wantRepository string
+		wantTag        string
+	}{
+		{"repository and tag", "myregistry.io/api:v1", "myregistry.io/api", "v1"},
+		{"no tag defaults to latest", "myregistry.io/api", "myregistry.io/api", "latest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repository, tag := splitImageRef(tt.imageRef)
+			if repository != tt.wantRepository {
+				t.Errorf("expected repository %q, got %q", tt.wantRepository, repository)
+			}
+
+			if tag != tt.wantTag {
+				t.Errorf("expected tag %q, got %q", tt.wantTag, tag)
+			}
+		})
+	}
+}
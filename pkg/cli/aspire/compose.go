@@ -0,0 +1,207 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// composeFile is the subset of the Docker Compose file format this adapter understands.
+type composeFile struct {
+	Services map[string]composeService `yaml:"services"`
+}
+
+// composeService is one entry under Compose's top-level "services" key.
+type composeService struct {
+	Image       string            `yaml:"image"`
+	Command     composeStringList `yaml:"command"`
+	Entrypoint  composeStringList `yaml:"entrypoint"`
+	Environment map[string]string `yaml:"environment"`
+	Ports       []string          `yaml:"ports"`
+	Volumes     []string          `yaml:"volumes"`
+}
+
+// composeStringList decodes a Compose field that may be given as either a single string
+// or a list of strings (e.g. "command: ./run.sh" vs "command: [\"./run.sh\", \"--flag\"]").
+type composeStringList []string
+
+func (l *composeStringList) UnmarshalYAML(value *yaml.Node) error {
+	var list []string
+	if err := value.Decode(&list); err == nil {
+		*l = list
+		return nil
+	}
+
+	var single string
+	if err := value.Decode(&single); err != nil {
+		return err
+	}
+
+	*l = strings.Fields(single)
+
+	return nil
+}
+
+// ComposeToManifest reads a Docker Compose file at path and converts it into an
+// AspireManifest, so it can run through the same translation pipeline as an Aspire
+// manifest (see TranslateOptions.ComposePath). Every service with an "image" becomes a
+// container.v0 resource; a service without one is skipped and reported in the manifest's
+// Warnings. Compose has no equivalent of Aspire's resource-reference expressions, so
+// inter-service connections (Compose's built-in per-service DNS) are not represented as
+// RadiusResource connections and rely on the container's own env vars/ports instead.
+func ComposeToManifest(path string) (*AspireManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("compose file not found: %s", path)
+		}
+
+		return nil, fmt.Errorf("failed to read compose file: %w", err)
+	}
+
+	var file composeFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse compose file: %w", err)
+	}
+
+	if len(file.Services) == 0 {
+		return nil, fmt.Errorf("failed to parse compose file: no services defined")
+	}
+
+	manifest := &AspireManifest{
+		Resources: make(map[string]ManifestResource, len(file.Services)),
+	}
+
+	for name, svc := range file.Services {
+		if svc.Image == "" {
+			manifest.Warnings = append(manifest.Warnings, fmt.Sprintf("service %q has no \"image\"; build-only Compose services are not yet supported and will be skipped", name))
+			continue
+		}
+
+		resource := ManifestResource{
+			Type:  "container.v0",
+			Image: svc.Image,
+			Args:  svc.Command,
+			Env:   svc.Environment,
+		}
+
+		if len(svc.Entrypoint) > 0 {
+			resource.Entrypoint = strings.Join(svc.Entrypoint, " ")
+		}
+
+		bindings, err := composeBindings(svc.Ports)
+		if err != nil {
+			return nil, fmt.Errorf("service %q: %w", name, err)
+		}
+
+		resource.Bindings = bindings
+
+		volumes, bindMounts := composeVolumes(svc.Volumes)
+		resource.Volumes = volumes
+		resource.BindMounts = bindMounts
+
+		manifest.Resources[name] = resource
+	}
+
+	return manifest, nil
+}
+
+// composeBindings converts Compose "ports" entries ("8080:80", "80", or "80/udp") into
+// Aspire manifest bindings, named "binding0", "binding1", ... in declaration order.
+func composeBindings(ports []string) (map[string]ManifestBinding, error) {
+	if len(ports) == 0 {
+		return nil, nil
+	}
+
+	bindings := make(map[string]ManifestBinding, len(ports))
+
+	for i, spec := range ports {
+		hostPort, containerPort, protocol, err := parseComposePort(spec)
+		if err != nil {
+			return nil, err
+		}
+
+		bindings[fmt.Sprintf("binding%d", i)] = ManifestBinding{
+			Protocol:   protocol,
+			Port:       hostPort,
+			TargetPort: containerPort,
+		}
+	}
+
+	return bindings, nil
+}
+
+// parseComposePort parses one Compose "ports" entry, which may omit the host port
+// ("80/tcp" publishes 80 to a random host port) or the protocol suffix (defaults to tcp).
+func parseComposePort(spec string) (hostPort, containerPort int, protocol string, err error) {
+	protocol = "tcp"
+
+	if idx := strings.Index(spec, "/"); idx != -1 {
+		protocol = spec[idx+1:]
+		spec = spec[:idx]
+	}
+
+	parts := strings.Split(spec, ":")
+
+	switch len(parts) {
+	case 1:
+		containerPort, err = strconv.Atoi(parts[0])
+	case 2:
+		hostPort, err = strconv.Atoi(parts[0])
+		if err == nil {
+			containerPort, err = strconv.Atoi(parts[1])
+		}
+	default:
+		return 0, 0, "", fmt.Errorf("unsupported ports entry %q", spec)
+	}
+
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("invalid ports entry %q: %w", spec, err)
+	}
+
+	return hostPort, containerPort, protocol, nil
+}
+
+// composeVolumes splits Compose "volumes" entries into named volume mounts (no host path,
+// e.g. "data:/var/lib/data") and bind mounts (host path present, e.g. "./cfg:/etc/app:ro").
+func composeVolumes(volumes []string) ([]ManifestVolumeMount, []ManifestBindMount) {
+	var namedVolumes []ManifestVolumeMount
+	var bindMounts []ManifestBindMount
+
+	for _, spec := range volumes {
+		parts := strings.Split(spec, ":")
+		if len(parts) < 2 {
+			continue
+		}
+
+		source, target := parts[0], parts[1]
+		readOnly := len(parts) > 2 && parts[2] == "ro"
+
+		if strings.HasPrefix(source, "./") || strings.HasPrefix(source, "/") || strings.HasPrefix(source, "../") {
+			bindMounts = append(bindMounts, ManifestBindMount{Source: source, Target: target, ReadOnly: readOnly})
+		} else {
+			namedVolumes = append(namedVolumes, ManifestVolumeMount{Name: source, Target: target, ReadOnly: readOnly})
+		}
+	}
+
+	return namedVolumes, bindMounts
+}
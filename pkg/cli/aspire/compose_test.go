@@ -0,0 +1,167 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeComposeFile(t *testing.T, content string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "docker-compose.yml")
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write compose file: %v", err)
+	}
+
+	return path
+}
+
+func TestComposeToManifest_BasicService(t *testing.T) {
+	t.Parallel()
+
+	path := writeComposeFile(t, `
+services:
+  api:
+    image: myapp/api:latest
+    environment:
+      LOG_LEVEL: debug
+    ports:
+      - "8080:80"
+`)
+
+	manifest, err := ComposeToManifest(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resource, ok := manifest.Resources["api"]
+	if !ok {
+		t.Fatalf("expected an \"api\" resource, got %v", manifest.Resources)
+	}
+
+	if resource.Type != "container.v0" {
+		t.Errorf("expected container.v0, got %q", resource.Type)
+	}
+
+	if resource.Image != "myapp/api:latest" {
+		t.Errorf("expected image myapp/api:latest, got %q", resource.Image)
+	}
+
+	if resource.Env["LOG_LEVEL"] != "debug" {
+		t.Errorf("expected LOG_LEVEL=debug, got %v", resource.Env)
+	}
+
+	binding, ok := resource.Bindings["binding0"]
+	if !ok {
+		t.Fatalf("expected a binding for the published port, got %v", resource.Bindings)
+	}
+
+	if binding.Port != 8080 || binding.TargetPort != 80 {
+		t.Errorf("expected host port 8080 -> target port 80, got %+v", binding)
+	}
+}
+
+func TestComposeToManifest_VolumesSplitNamedFromBindMounts(t *testing.T) {
+	t.Parallel()
+
+	path := writeComposeFile(t, `
+services:
+  db:
+    image: postgres:16
+    volumes:
+      - data:/var/lib/postgresql/data
+      - ./conf:/etc/postgresql:ro
+`)
+
+	manifest, err := ComposeToManifest(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resource := manifest.Resources["db"]
+
+	if len(resource.Volumes) != 1 || resource.Volumes[0].Name != "data" {
+		t.Errorf("expected one named volume \"data\", got %v", resource.Volumes)
+	}
+
+	if len(resource.BindMounts) != 1 || resource.BindMounts[0].Source != "./conf" || !resource.BindMounts[0].ReadOnly {
+		t.Errorf("expected one read-only bind mount from ./conf, got %v", resource.BindMounts)
+	}
+}
+
+func TestComposeToManifest_ServiceWithoutImageIsSkippedWithWarning(t *testing.T) {
+	t.Parallel()
+
+	path := writeComposeFile(t, `
+services:
+  builder:
+    build: .
+  api:
+    image: myapp/api:latest
+`)
+
+	manifest, err := ComposeToManifest(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := manifest.Resources["builder"]; ok {
+		t.Errorf("expected the build-only service to be skipped, got %v", manifest.Resources)
+	}
+
+	if _, ok := manifest.Resources["api"]; !ok {
+		t.Errorf("expected the api service to still be translated, got %v", manifest.Resources)
+	}
+
+	found := false
+	for _, w := range manifest.Warnings {
+		if strings.Contains(w, "builder") {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Errorf("expected a warning about the build-only service, got %v", manifest.Warnings)
+	}
+}
+
+func TestTranslate_ComposePath(t *testing.T) {
+	t.Parallel()
+
+	path := writeComposeFile(t, `
+services:
+  api:
+    image: myapp/api:latest
+    ports:
+      - "8080:80"
+`)
+
+	result, err := Translate(TranslateOptions{ComposePath: path, AppName: "app"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(result.Bicep, "Applications.Core/containers") {
+		t.Errorf("expected a translated container resource, got:\n%s", result.Bicep)
+	}
+}
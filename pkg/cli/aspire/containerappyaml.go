@@ -0,0 +1,307 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// containerAppYAMLTemplate renders a single Azure Container Apps `containerapp` YAML
+// manifest for one translated container resource.
+const containerAppYAMLTemplate = `apiVersion: 2023-05-01
+kind: ContainerApp
+name: {{ .Name }}
+properties:
+{{- if or .Ports .Secrets .DaprPubSubComponents }}
+  configuration:
+{{- if .Ports }}
+    ingress:
+      external: {{ .External }}
+      targetPort: {{ .TargetPort }}
+      transport: auto
+{{- end }}
+{{- if .Secrets }}
+    secrets:
+{{- range .Secrets }}
+      - name: {{ . }}
+{{- end }}
+{{- end }}
+{{- if .DaprPubSubComponents }}
+    dapr:
+      enabled: true
+      appId: {{ .Name }}
+{{- end }}
+{{- end }}
+  template:
+    scale:
+      minReplicas: {{ .MinReplicas }}
+      maxReplicas: {{ .MaxReplicas }}
+{{- if .DaprPubSubComponents }}
+    dapr:
+      components:
+{{- range .DaprPubSubComponents }}
+        - name: {{ . }}
+          type: pubsub.rabbitmq
+{{- end }}
+{{- end }}
+    containers:
+      - name: {{ .Name }}
+        image: {{ .Image }}
+{{- if .Command }}
+        command:
+{{- range .Command }}
+          - {{ . }}
+{{- end }}
+{{- end }}
+{{- if .Args }}
+        args:
+{{- range .Args }}
+          - {{ . }}
+{{- end }}
+{{- end }}
+{{- if .Env }}
+        env:
+{{- range .Env }}
+          - name: {{ .Name }}
+{{- if .SecretRef }}
+            secretRef: {{ .SecretRef }}
+{{- else }}
+            value: {{ printf "%q" .Value }}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- if .ManagedCacheRefs }}
+    services:
+{{- range .ManagedCacheRefs }}
+      - type: redis
+        name: {{ . }}
+{{- end }}
+{{- end }}
+`
+
+// acaContainerData is the data passed to containerAppYAMLTemplate for one container.
+type acaContainerData struct {
+	Name                 string
+	Image                string
+	Command              []string
+	Args                 []string
+	Env                  []acaEnvEntry
+	Ports                map[string]PortSpec
+	TargetPort           int
+	External             bool
+	Secrets              []string
+	DaprPubSubComponents []string
+	ManagedCacheRefs     []string
+	MinReplicas          int
+	MaxReplicas          int
+}
+
+// Default scale bounds for an emitted Container Apps manifest. Aspire manifests carry no
+// replica-count information of their own, so these match the Container Apps platform's own
+// defaults; a container's MinReplicas/MaxReplicas (set by a manifest overlay's scale block)
+// replace them when present.
+const (
+	acaDefaultMinReplicas = 1
+	acaDefaultMaxReplicas = 10
+)
+
+// acaEnvEntry is one rendered environment variable entry.
+type acaEnvEntry struct {
+	Name      string
+	Value     string
+	SecretRef string
+}
+
+// emitContainerAppYAML renders one Azure Container Apps YAML manifest per
+// Applications.Core/containers resource in ctx, keyed by filename
+// ("<identifier>.containerapp.yaml"). Portable resource connections degrade to their
+// closest ACA-native equivalent (Redis -> a managed "services" binding, RabbitMQ -> a
+// Dapr pub-sub component) where one exists; connections to resources with no ACA-native
+// equivalent (SQL, MongoDB) fall back to the env vars already carrying their connection
+// details, and a warning is recorded via ctx.addWarning.
+func emitContainerAppYAML(ctx *translationContext) (map[string]string, error) {
+	tmpl, err := template.New("containerapp").Parse(containerAppYAMLTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Container Apps template: %w", err)
+	}
+
+	var names []string
+	for name, res := range ctx.resources {
+		if res.Kind == KindContainer {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	result := make(map[string]string, len(names))
+
+	for _, name := range names {
+		res := ctx.resources[name]
+
+		data := acaContainerData{
+			Name:        res.BicepIdentifier,
+			Image:       res.Container.Image,
+			Command:     res.Container.Command,
+			Args:        res.Container.Args,
+			Ports:       res.Container.Ports,
+			MinReplicas: acaDefaultMinReplicas,
+			MaxReplicas: acaDefaultMaxReplicas,
+		}
+
+		if res.Container.ImageParam != "" {
+			data.Image = res.Container.ImageParam
+		}
+
+		if res.Container.MinReplicas != nil {
+			data.MinReplicas = *res.Container.MinReplicas
+		}
+
+		if res.Container.MaxReplicas != nil {
+			data.MaxReplicas = *res.Container.MaxReplicas
+		}
+
+		if len(res.Container.Ports) > 0 {
+			data.TargetPort, data.External = acaIngressFromPorts(res.Container.Ports)
+		}
+
+		data.Env, data.Secrets = acaBuildEnv(name, res.Container.Env, ctx.parameters, ctx)
+
+		for connName := range res.Connections {
+			switch ctx.kindMap[connName] {
+			case KindRedisCache:
+				data.ManagedCacheRefs = append(data.ManagedCacheRefs, connName)
+			case KindRabbitMQ:
+				data.DaprPubSubComponents = append(data.DaprPubSubComponents, connName)
+			case KindSQLDB, KindMongoDB:
+				ctx.addWarning(fmt.Sprintf("Resource %q: connection to %q (%s) has no Container Apps-native mapping; relying on its env vars for connectivity", name, connName, ctx.kindMap[connName]))
+			}
+		}
+
+		sort.Strings(data.ManagedCacheRefs)
+		sort.Strings(data.DaprPubSubComponents)
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render Container Apps manifest for %q: %w", name, err)
+		}
+
+		result[res.BicepIdentifier+".containerapp.yaml"] = buf.String()
+	}
+
+	return result, nil
+}
+
+// acaIngressFromPorts picks the lowest-named port as the ingress target port, and marks
+// the ingress external when that same port uses an http/https scheme.
+func acaIngressFromPorts(ports map[string]PortSpec) (targetPort int, external bool) {
+	var names []string
+	for name := range ports {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	chosen := ports[names[0]]
+	targetPort = chosen.ContainerPort
+
+	scheme := strings.ToLower(chosen.Scheme)
+	external = scheme == "http" || scheme == "https"
+
+	return targetPort, external
+}
+
+// acaBuildEnv renders a container's env map into a sorted slice of acaEnvEntry, and
+// collects the ACA secret names referenced by env vars that resolve directly to a
+// @secure() Bicep parameter. Env vars backed by a Radius secretStore (SecretRef) have no
+// value ACA can resolve on its own, so they fall back to their literal Value (if any) and
+// record a translation warning, the same degradation used for SQL/MongoDB connections.
+func acaBuildEnv(resourceName string, env map[string]EnvVarSpec, params []BicepParameter, ctx *translationContext) ([]acaEnvEntry, []string) {
+	var keys []string
+	for key := range env {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var entries []acaEnvEntry
+	var secrets []string
+
+	for _, key := range keys {
+		spec := env[key]
+
+		if spec.SecretRef != nil {
+			ctx.addWarning(fmt.Sprintf("Resource %q: env var %q is backed by a Radius secret store, which Container Apps YAML cannot reference; falling back to its literal value", resourceName, key))
+			entries = append(entries, acaEnvEntry{Name: key, Value: spec.Value})
+			continue
+		}
+
+		if _, ok := acaSecureParamRef(spec, params); ok {
+			secretName := acaSecretName(key)
+			entries = append(entries, acaEnvEntry{Name: key, SecretRef: secretName})
+			secrets = append(secrets, secretName)
+			continue
+		}
+
+		entries = append(entries, acaEnvEntry{Name: key, Value: spec.Value})
+	}
+
+	return entries, secrets
+}
+
+// acaSecureParamRef returns the @secure() Bicep parameter name that env resolves directly
+// to (e.g. Value == "${dbPassword}"), or ok=false if env isn't a bare reference to one.
+func acaSecureParamRef(env EnvVarSpec, params []BicepParameter) (string, bool) {
+	if !env.IsBicepInterpolation {
+		return "", false
+	}
+
+	name := strings.TrimSuffix(strings.TrimPrefix(env.Value, "${"), "}")
+	if name == env.Value {
+		return "", false
+	}
+
+	for _, p := range params {
+		if p.Secure && p.Name == name {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+// acaSecretName derives an ACA secret name (lowercase, dash-separated) from an env var key.
+func acaSecretName(envKey string) string {
+	var b strings.Builder
+	for i := 0; i < len(envKey); i++ {
+		c := envKey[i]
+		switch {
+		case c >= 'A' && c <= 'Z':
+			b.WriteByte(c - 'A' + 'a')
+		case c == '_':
+			b.WriteByte('-')
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String()
+}
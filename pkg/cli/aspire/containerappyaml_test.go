@@ -0,0 +1,212 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEmitContainerAppYAML_BasicContainer(t *testing.T) {
+	t.Parallel()
+
+	ctx := newTranslationContext(&AspireManifest{}, &translationConfig{})
+	ctx.resources["api"] = &RadiusResource{
+		BicepIdentifier: "api",
+		Kind:            KindContainer,
+		Container: &ContainerSpec{
+			Image: "myapp/api:latest",
+			Ports: map[string]PortSpec{
+				"http": {ContainerPort: 8080, Scheme: "http"},
+			},
+			Env: map[string]EnvVarSpec{
+				"LOG_LEVEL": {Value: "debug"},
+			},
+		},
+	}
+	ctx.kindMap["api"] = KindContainer
+
+	files, err := emitContainerAppYAML(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, ok := files["api.containerapp.yaml"]
+	if !ok {
+		t.Fatalf("expected api.containerapp.yaml in output, got %v", files)
+	}
+
+	if !strings.Contains(content, "targetPort: 8080") {
+		t.Errorf("expected targetPort 8080, got:\n%s", content)
+	}
+
+	if !strings.Contains(content, "external: true") {
+		t.Errorf("expected external ingress for http scheme, got:\n%s", content)
+	}
+
+	if !strings.Contains(content, `value: "debug"`) {
+		t.Errorf("expected LOG_LEVEL env value, got:\n%s", content)
+	}
+
+	if !strings.Contains(content, "minReplicas: 1") || !strings.Contains(content, "maxReplicas: 10") {
+		t.Errorf("expected default scale bounds, got:\n%s", content)
+	}
+}
+
+func TestEmitContainerAppYAML_IngressMatchesChosenTargetPort(t *testing.T) {
+	t.Parallel()
+
+	ctx := newTranslationContext(&AspireManifest{}, &translationConfig{})
+	ctx.resources["api"] = &RadiusResource{
+		BicepIdentifier: "api",
+		Kind:            KindContainer,
+		Container: &ContainerSpec{
+			Image: "myapp/api:latest",
+			Ports: map[string]PortSpec{
+				"admin": {ContainerPort: 9090, Scheme: "tcp"},
+				"web":   {ContainerPort: 8080, Scheme: "http"},
+			},
+		},
+	}
+	ctx.kindMap["api"] = KindContainer
+
+	files, err := emitContainerAppYAML(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content := files["api.containerapp.yaml"]
+
+	// "admin" sorts before "web", so it is the chosen target port; external must reflect
+	// its tcp scheme, not the http scheme of the other port.
+	if !strings.Contains(content, "targetPort: 9090") {
+		t.Errorf("expected targetPort 9090 (the alphabetically-first port), got:\n%s", content)
+	}
+
+	if !strings.Contains(content, "external: false") {
+		t.Errorf("expected external false since the chosen port uses tcp, got:\n%s", content)
+	}
+}
+
+func TestEmitContainerAppYAML_NoIngressSecretsOrDaprOmitsConfiguration(t *testing.T) {
+	t.Parallel()
+
+	ctx := newTranslationContext(&AspireManifest{}, &translationConfig{})
+	ctx.resources["worker"] = &RadiusResource{
+		BicepIdentifier: "worker",
+		Kind:            KindContainer,
+		Container: &ContainerSpec{
+			Image: "myapp/worker:latest",
+		},
+	}
+	ctx.kindMap["worker"] = KindContainer
+
+	files, err := emitContainerAppYAML(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content := files["worker.containerapp.yaml"]
+
+	if strings.Contains(content, "configuration:") {
+		t.Errorf("expected no configuration key when there is no ingress, secrets, or dapr, got:\n%s", content)
+	}
+}
+
+func TestEmitContainerAppYAML_SecureParamBecomesSecretRef(t *testing.T) {
+	t.Parallel()
+
+	ctx := newTranslationContext(&AspireManifest{}, &translationConfig{})
+	ctx.parameters = []BicepParameter{
+		{Name: "dbPassword", Secure: true},
+	}
+	ctx.resources["api"] = &RadiusResource{
+		BicepIdentifier: "api",
+		Kind:            KindContainer,
+		Container: &ContainerSpec{
+			Image: "myapp/api:latest",
+			Env: map[string]EnvVarSpec{
+				"DB_PASSWORD": {Value: "${dbPassword}", IsBicepInterpolation: true},
+			},
+		},
+	}
+	ctx.kindMap["api"] = KindContainer
+
+	files, err := emitContainerAppYAML(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content := files["api.containerapp.yaml"]
+
+	if !strings.Contains(content, "secrets:") || !strings.Contains(content, "name: db-password") {
+		t.Errorf("expected db-password secret declaration, got:\n%s", content)
+	}
+
+	if !strings.Contains(content, "secretRef: db-password") {
+		t.Errorf("expected DB_PASSWORD to reference the secret, got:\n%s", content)
+	}
+}
+
+func TestEmitContainerAppYAML_PortableResourceDegradation(t *testing.T) {
+	t.Parallel()
+
+	ctx := newTranslationContext(&AspireManifest{}, &translationConfig{})
+	ctx.resources["api"] = &RadiusResource{
+		BicepIdentifier: "api",
+		Kind:            KindContainer,
+		Container: &ContainerSpec{
+			Image: "myapp/api:latest",
+			Env:   map[string]EnvVarSpec{},
+		},
+		Connections: map[string]ConnectionSpec{
+			"cache": {Source: "redis connection string"},
+			"bus":   {Source: "rabbitmq connection string"},
+			"db":    {Source: "sql connection string"},
+		},
+	}
+	ctx.kindMap["api"] = KindContainer
+	ctx.kindMap["cache"] = KindRedisCache
+	ctx.kindMap["bus"] = KindRabbitMQ
+	ctx.kindMap["db"] = KindSQLDB
+
+	files, err := emitContainerAppYAML(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content := files["api.containerapp.yaml"]
+
+	if !strings.Contains(content, "type: redis") || !strings.Contains(content, "name: cache") {
+		t.Errorf("expected a redis managed cache service binding, got:\n%s", content)
+	}
+
+	if !strings.Contains(content, "type: pubsub.rabbitmq") {
+		t.Errorf("expected a dapr pubsub component for the rabbitmq connection, got:\n%s", content)
+	}
+
+	found := false
+	for _, w := range ctx.warnings {
+		if strings.Contains(w, "db") && strings.Contains(w, "no Container Apps-native mapping") {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Errorf("expected a warning about the SQL connection having no ACA-native mapping, got %v", ctx.warnings)
+	}
+}
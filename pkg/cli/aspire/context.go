@@ -41,6 +41,53 @@ type translationContext struct {
 
 	// kindMap maps original Aspire resource names to their classified ResourceKind.
 	kindMap map[string]ResourceKind
+
+	// handlerMap maps original Aspire resource names to the ResourceHandler registered
+	// for their manifest "type", for resources whose kind falls outside the built-in
+	// container/portable/value/parameter categories mapResources and resolveSingleExpression
+	// already handle directly.
+	handlerMap map[string]ResourceHandler
+
+	// builtImages maps resource names to images produced by an automatic build
+	// (BuildModeBuildpacks, BuildModeTektonPipeline, BuildModeDotnetPublish or
+	// BuildModeDockerfile).
+	builtImages map[string]string
+
+	// tektonProjects accumulates project resources that need a build Task in the
+	// generated Tekton pipeline. Only populated in BuildModeTektonPipeline.
+	tektonProjects []tektonProject
+
+	// origin maps each resource name in manifest to the path of the aspire-manifest.json
+	// it came from. Populated for every translation; has more than one distinct value only
+	// for multi-manifest workspaces.
+	origin map[string]string
+
+	// secretKeys maps sanitized parameter names to their default value (if any), for
+	// parameter.v0 resources marked secret when config.secretBackend is not
+	// SecretBackendBicepParam. Populated during mapParameter, consumed to synthesize the
+	// secretStores resource once all resources have been mapped.
+	secretKeys map[string]string
+
+	// env holds the values available to "{env:VAR}" expression lookups (see
+	// TranslateOptions.Env). Looking up a name not present here is treated as unset.
+	env map[string]string
+
+	// imageDigests caches config.registryResolver's resolved digest per image reference,
+	// so translating a manifest with the same image on multiple resources only hits the
+	// registry once. A cached value of "" means the image carried no rewritable digest.
+	imageDigests map[string]string
+
+	// imagePorts caches config.registryResolver's resolved exposed ports per image
+	// reference, for the same reason as imageDigests.
+	imagePorts map[string][]ExposedPort
+
+	// resolvingPairs tracks (sourceResource, targetResource) edges that
+	// resolveConnectionString/resolveValueReference are currently in the middle of
+	// resolving, keyed as "source->target". It's a runtime backstop: detectCircularReferences
+	// should reject every cycle before resolution starts, but if one slips through, revisiting
+	// an edge already on the stack returns a connectionStringCycleError instead of recursing
+	// until the stack overflows.
+	resolvingPairs map[string]bool
 }
 
 // translationConfig holds user-provided configuration that controls translation behavior.
@@ -59,16 +106,82 @@ type translationConfig struct {
 
 	// outputDir is the directory to write app.bicep. Defaults to current directory.
 	outputDir string
+
+	// buildMode controls how project resources without an image mapping are built.
+	buildMode BuildMode
+
+	// registry is the container registry built images are tagged for, and pushed to when
+	// pushImages is set.
+	registry string
+
+	// pushImages, when set, pushes images built locally (BuildModeBuildpacks,
+	// BuildModeDockerfile, BuildModeDotnetPublish) to registry after a successful build.
+	// Has no effect on BuildModeTektonPipeline, whose generated pipeline pushes the image
+	// itself, or BuildModePlaceholderParam, which never builds locally.
+	pushImages bool
+
+	// builderImage overrides the default Cloud Native Buildpacks builder image.
+	builderImage string
+
+	// secretBackend controls how secret parameter.v0 resources are surfaced in Bicep.
+	secretBackend SecretBackend
+
+	// secretStoreName is the Radius name given to the synthesized secretStores resource.
+	secretStoreName string
+
+	// registryResolver, when set, classifies container.v0/v1 resources by inspecting their
+	// remote image config instead of (or in addition to) matching the image name against
+	// backingServiceTable. Nil disables resolver-based detection.
+	registryResolver RegistryResolver
+
+	// emitFormat selects which backend renders the translated resources. Defaults to
+	// FormatBicep.
+	emitFormat EmitFormat
+
+	// templateDir, when set, overrides the Bicep emitter's built-in per-resource-kind
+	// templates with files of the same name found in this directory. See
+	// TranslateOptions.TemplateDir.
+	templateDir string
+
+	// gatewayMode selects how synthesizeGateway exposes external bindings. Defaults to
+	// GatewayModeIngress.
+	gatewayMode GatewayMode
+
+	// strictSchema upgrades validateManifestSchema's missing/unrecognized-$schema warning
+	// into a hard error. See TranslateOptions.StrictSchema.
+	strictSchema bool
+
+	// mode selects whether translateManifest generates fresh output or reports drift
+	// against existingOutputDir. Defaults to ModeGenerate.
+	mode Mode
+
+	// existingOutputDir is the directory detectDrift compares the regenerated Bicep
+	// against when mode is ModeDrift. See TranslateOptions.ExistingOutputDir.
+	existingOutputDir string
+
+	// gatewayAPI selects whether FormatKubernetesYAML/FormatHelm render gateway routes as
+	// a Gateway API HTTPRoute instead of a plain Ingress. See TranslateOptions.GatewayAPI.
+	gatewayAPI bool
+
+	// manifestOverlays maps a container resource name to its raw overlay snippet. See
+	// TranslateOptions.ManifestOverlays.
+	manifestOverlays map[string]string
 }
 
 // newTranslationContext creates a new translationContext with the given manifest and config.
 func newTranslationContext(manifest *AspireManifest, config *translationConfig) *translationContext {
 	return &translationContext{
-		manifest:  manifest,
-		config:    config,
-		resources: make(map[string]*RadiusResource),
-		nameMap:   make(map[string]string),
-		kindMap:   make(map[string]ResourceKind),
+		manifest:       manifest,
+		config:         config,
+		resources:      make(map[string]*RadiusResource),
+		nameMap:        make(map[string]string),
+		kindMap:        make(map[string]ResourceKind),
+		handlerMap:     make(map[string]ResourceHandler),
+		builtImages:    make(map[string]string),
+		secretKeys:     make(map[string]string),
+		imageDigests:   make(map[string]string),
+		imagePorts:     make(map[string][]ExposedPort),
+		resolvingPairs: make(map[string]bool),
 	}
 }
 
@@ -53,23 +53,23 @@ var backingServiceTable = []backingServiceEntry{
 	{prefix: "rabbitmq", kind: KindRabbitMQ},
 }
 
-// extractBaseImageName extracts the base image name from a full image reference.
-// For example: "docker.io/bitnami/redis:7" → "redis"
+// extractBaseImageName extracts the base image name (the final path segment) from a
+// full image reference, via ParseReference. For example:
 //
+//	"docker.io/bitnami/redis:7" → "redis"
 //	"redis:latest" → "redis"
 //	"myregistry.io/library/postgres:14" → "postgres"
+//	"localhost:5000/redis" → "redis"
+//	"postgres@sha256:abcd..." → "postgres"
+//
+// If image cannot be parsed as a reference, it is returned unchanged.
 func extractBaseImageName(image string) string {
-	// Remove tag (everything after the last colon, but handle ports).
-	name := image
-
-	// Split on "/" and take the last segment.
-	parts := strings.Split(name, "/")
-	lastPart := parts[len(parts)-1]
-
-	// Remove tag (after colon).
-	if idx := strings.LastIndex(lastPart, ":"); idx != -1 {
-		lastPart = lastPart[:idx]
+	ref, err := ParseReference(image)
+	if err != nil {
+		return image
 	}
 
-	return lastPart
+	segments := strings.Split(ref.Path, "/")
+
+	return segments[len(segments)-1]
 }
@@ -128,6 +128,26 @@ func TestExtractBaseImageName(t *testing.T) {
 			image:    "myregistry.io/library/postgres:14",
 			expected: "postgres",
 		},
+		{
+			name:     "registry with port",
+			image:    "localhost:5000/redis:7",
+			expected: "redis",
+		},
+		{
+			name:     "digest reference",
+			image:    "postgres@sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+			expected: "postgres",
+		},
+		{
+			name:     "tagged digest reference",
+			image:    "myregistry.io/redis:7@sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+			expected: "redis",
+		},
+		{
+			name:     "multi-segment path",
+			image:    "myregistry.io/org/team/redis:7",
+			expected: "redis",
+		},
 	}
 
 	for _, tt := range tests {
@@ -182,7 +202,7 @@ func TestClassify_BackingServiceDetection(t *testing.T) {
 				}
 			}
 
-			result := classify(tt.resName, tt.resource, overrides)
+			result := classify(tt.resName, tt.resource, &translationConfig{resourceOverrides: overrides}, nil)
 			if result != tt.expected {
 				t.Errorf("classify(%q) = %q, want %q", tt.resName, result, tt.expected)
 			}
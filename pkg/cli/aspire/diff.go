@@ -0,0 +1,346 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// PropertyChange is one field-level difference found between two versions of the same
+// manifest resource, named by a JSON pointer path into the decoded ManifestResource (e.g.
+// "/image", "/env/REDIS_URL", "/bindings/http/port").
+type PropertyChange struct {
+	// Path is a JSON pointer (RFC 6901) into the resource, rooted at the resource itself.
+	Path string
+
+	// OldValue is the value at Path in the previous manifest, or nil if Path didn't exist
+	// there (the property was added).
+	OldValue any
+
+	// NewValue is the value at Path in the next manifest, or nil if Path no longer exists
+	// there (the property was removed).
+	NewValue any
+}
+
+// DiffResult is the result of TranslateDiff.
+type DiffResult struct {
+	// AddedResources lists resource names present in next but not in prev.
+	AddedResources []string
+
+	// RemovedResources lists resource names present in prev but not in next.
+	RemovedResources []string
+
+	// ModifiedResources maps a resource name to its property-level changes. A resource
+	// detected as renamed (see TranslateDiff) is keyed under its new name and carries a
+	// synthetic "/name" PropertyChange alongside whatever else changed, rather than
+	// appearing in AddedResources/RemovedResources.
+	ModifiedResources map[string][]PropertyChange
+
+	// UnifiedDiff is a unified-format diff of prev's rendered Bicep against next's.
+	UnifiedDiff string
+}
+
+// TranslateDiff runs Translate on prev and next and returns a structured diff between
+// them: which resources were added, removed, or modified, and a unified diff of the two
+// rendered Bicep outputs. It's meant for CI workflows that want to summarize an Aspire
+// manifest change (e.g. as a PR comment) without re-rendering the whole output.
+//
+// Because both sides run the same deterministic translation pipeline, a resource whose
+// name changed but whose type and resolved connection targets didn't is reported as a
+// modification (with a "/name" PropertyChange) rather than as a delete-and-add pair — see
+// renameCandidates.
+func TranslateDiff(prev, next TranslateOptions) (*DiffResult, error) {
+	prevResult, err := Translate(prev)
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate prev: %w", err)
+	}
+
+	nextResult, err := Translate(next)
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate next: %w", err)
+	}
+
+	prevManifest, err := loadDiffManifest(prev)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load prev manifest: %w", err)
+	}
+
+	nextManifest, err := loadDiffManifest(next)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load next manifest: %w", err)
+	}
+
+	unified, err := unifiedDiff("app.bicep", prevResult.Bicep, nextResult.Bicep)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DiffResult{
+		ModifiedResources: map[string][]PropertyChange{},
+		UnifiedDiff:       unified,
+	}
+
+	var removed, added []string
+	for name := range prevManifest.Resources {
+		if _, ok := nextManifest.Resources[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	for name := range nextManifest.Resources {
+		if _, ok := prevManifest.Resources[name]; !ok {
+			added = append(added, name)
+		}
+	}
+	sort.Strings(removed)
+	sort.Strings(added)
+
+	renamed, removed, added := renameCandidates(prevManifest, nextManifest, removed, added)
+	for oldName, newName := range renamed {
+		changes := diffResourceProperties(prevManifest.Resources[oldName], nextManifest.Resources[newName])
+		changes = append([]PropertyChange{{Path: "/name", OldValue: oldName, NewValue: newName}}, changes...)
+		result.ModifiedResources[newName] = changes
+	}
+
+	result.AddedResources = added
+	result.RemovedResources = removed
+
+	for name, nextResource := range nextManifest.Resources {
+		prevResource, ok := prevManifest.Resources[name]
+		if !ok {
+			continue
+		}
+
+		if changes := diffResourceProperties(prevResource, nextResource); len(changes) > 0 {
+			result.ModifiedResources[name] = changes
+		}
+	}
+
+	return result, nil
+}
+
+// loadDiffManifest loads the merged AspireManifest TranslateDiff needs to compute a
+// per-resource diff, following the same ComposePath/WorkspacePath/ManifestPaths/
+// ManifestPath precedence Translate itself uses.
+func loadDiffManifest(opts TranslateOptions) (*AspireManifest, error) {
+	if opts.ComposePath != "" {
+		return ComposeToManifest(opts.ComposePath)
+	}
+
+	paths, err := resolveManifestPaths(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, _, err := loadManifests(paths)
+	return manifest, err
+}
+
+// renameCandidates pairs off names in removed against names in added that look like the
+// same resource renamed rather than one resource deleted and an unrelated one added: same
+// Type, and the same set of resolved connection targets (see connectionTargets). Ties are
+// broken by preferring the pairing with fewer remaining property differences. Returns the
+// rename map (old name -> new name) plus the remaining, unpaired removed/added slices.
+func renameCandidates(prevManifest, nextManifest *AspireManifest, removed, added []string) (map[string]string, []string, []string) {
+	renamed := map[string]string{}
+	usedAdded := map[string]bool{}
+
+	var remainingRemoved []string
+
+	for _, oldName := range removed {
+		oldResource := prevManifest.Resources[oldName]
+		oldTargets := connectionTargets(oldResource)
+
+		bestName := ""
+		bestChanges := -1
+
+		for _, newName := range added {
+			if usedAdded[newName] {
+				continue
+			}
+
+			newResource := nextManifest.Resources[newName]
+			if newResource.Type != oldResource.Type {
+				continue
+			}
+
+			if !sameStringSet(oldTargets, connectionTargets(newResource)) {
+				continue
+			}
+
+			changes := len(diffResourceProperties(oldResource, newResource))
+			if bestName == "" || changes < bestChanges {
+				bestName, bestChanges = newName, changes
+			}
+		}
+
+		if bestName != "" {
+			renamed[oldName] = bestName
+			usedAdded[bestName] = true
+			continue
+		}
+
+		remainingRemoved = append(remainingRemoved, oldName)
+	}
+
+	var remainingAdded []string
+	for _, newName := range added {
+		if !usedAdded[newName] {
+			remainingAdded = append(remainingAdded, newName)
+		}
+	}
+
+	return renamed, remainingRemoved, remainingAdded
+}
+
+// connectionTargets returns the sorted, deduplicated set of resource names resource
+// resolves a connection to, via its ConnectionString or any Env var — the same dependency
+// edges detectCircularReferences collects, minus the cycle bookkeeping.
+func connectionTargets(resource ManifestResource) []string {
+	var edges []depEdge
+
+	if resource.ConnectionString != "" {
+		cv := parseExpressions(resource.ConnectionString)
+		for _, part := range cv.parts {
+			edges = append(edges, collectDeps(part.expression, false)...)
+		}
+	}
+
+	for _, value := range resource.Env {
+		cv := parseExpressions(value)
+		for _, part := range cv.parts {
+			edges = append(edges, collectDeps(part.expression, true)...)
+		}
+	}
+
+	seen := map[string]bool{}
+	var targets []string
+	for _, edge := range edges {
+		if !seen[edge.to] {
+			seen[edge.to] = true
+			targets = append(targets, edge.to)
+		}
+	}
+	sort.Strings(targets)
+
+	return targets
+}
+
+// sameStringSet reports whether a and b contain the same elements, ignoring order.
+// Both must already be sorted (connectionTargets returns sorted slices).
+func sameStringSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// diffResourceProperties returns the JSON-pointer-addressed property changes between old
+// and new, by round-tripping both through JSON (the same encoding ManifestResource uses
+// for the Aspire manifest) and comparing them structurally. Map keys are visited in
+// sorted order so the result is deterministic.
+func diffResourceProperties(old, updated ManifestResource) []PropertyChange {
+	oldValue := toJSONValue(old)
+	newValue := toJSONValue(updated)
+
+	var changes []PropertyChange
+	collectPropertyChanges("", oldValue, newValue, &changes)
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+
+	return changes
+}
+
+// toJSONValue marshals v through encoding/json and decodes it back into plain
+// map[string]any/[]any/scalar values, so collectPropertyChanges can walk it generically.
+func toJSONValue(v ManifestResource) any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil
+	}
+
+	return decoded
+}
+
+// collectPropertyChanges recursively compares old and new (decoded JSON values) and
+// appends a PropertyChange to out for every leaf or object-shape difference, addressed by
+// a JSON pointer rooted at path. Two maps recurse key-by-key; anything else (including
+// arrays, which are compared as a whole rather than element-by-element since Args/Options
+// order is significant) is compared wholesale.
+func collectPropertyChanges(path string, old, updated any, out *[]PropertyChange) {
+	oldMap, oldIsMap := old.(map[string]any)
+	newMap, newIsMap := updated.(map[string]any)
+
+	if oldIsMap && newIsMap {
+		keys := make(map[string]bool, len(oldMap)+len(newMap))
+		for k := range oldMap {
+			keys[k] = true
+		}
+		for k := range newMap {
+			keys[k] = true
+		}
+
+		sortedKeys := make([]string, 0, len(keys))
+		for k := range keys {
+			sortedKeys = append(sortedKeys, k)
+		}
+		sort.Strings(sortedKeys)
+
+		for _, k := range sortedKeys {
+			collectPropertyChanges(path+"/"+jsonPointerEscape(k), oldMap[k], newMap[k], out)
+		}
+
+		return
+	}
+
+	if reflect.DeepEqual(old, updated) {
+		return
+	}
+
+	*out = append(*out, PropertyChange{Path: path, OldValue: old, NewValue: updated})
+}
+
+// jsonPointerEscape escapes a raw object key for use as a JSON pointer (RFC 6901) segment.
+func jsonPointerEscape(key string) string {
+	escaped := make([]rune, 0, len(key))
+	for _, r := range key {
+		switch r {
+		case '~':
+			escaped = append(escaped, '~', '0')
+		case '/':
+			escaped = append(escaped, '~', '1')
+		default:
+			escaped = append(escaped, r)
+		}
+	}
+
+	return string(escaped)
+}
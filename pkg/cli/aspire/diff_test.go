@@ -0,0 +1,201 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeDiffManifest writes content to a scratch aspire-manifest.json under t.TempDir() and
+// returns its path.
+func writeDiffManifest(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "aspire-manifest.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestTranslateDiff_AddsResource(t *testing.T) {
+	t.Parallel()
+
+	prevPath := writeDiffManifest(t, `{"resources":{
+		"api": {"type": "container.v0", "image": "myapp/api:latest"}
+	}}`)
+	nextPath := writeDiffManifest(t, `{"resources":{
+		"api": {"type": "container.v0", "image": "myapp/api:latest"},
+		"queue": {"type": "container.v0", "image": "rabbitmq:3.13"}
+	}}`)
+
+	result, err := TranslateDiff(
+		TranslateOptions{ManifestPath: prevPath, AppName: "app"},
+		TranslateOptions{ManifestPath: nextPath, AppName: "app"},
+	)
+	if err != nil {
+		t.Fatalf("TranslateDiff failed: %v", err)
+	}
+
+	if len(result.AddedResources) != 1 || result.AddedResources[0] != "queue" {
+		t.Errorf("expected AddedResources to be [\"queue\"], got %v", result.AddedResources)
+	}
+	if len(result.RemovedResources) != 0 {
+		t.Errorf("expected no removed resources, got %v", result.RemovedResources)
+	}
+	if len(result.ModifiedResources) != 0 {
+		t.Errorf("expected no modified resources, got %v", result.ModifiedResources)
+	}
+	if result.UnifiedDiff == "" {
+		t.Error("expected a non-empty unified diff")
+	}
+}
+
+func TestTranslateDiff_RemovesResource(t *testing.T) {
+	t.Parallel()
+
+	prevPath := writeDiffManifest(t, `{"resources":{
+		"api": {"type": "container.v0", "image": "myapp/api:latest"},
+		"worker": {"type": "container.v0", "image": "myapp/worker:latest"}
+	}}`)
+	nextPath := writeDiffManifest(t, `{"resources":{
+		"api": {"type": "container.v0", "image": "myapp/api:latest"}
+	}}`)
+
+	result, err := TranslateDiff(
+		TranslateOptions{ManifestPath: prevPath, AppName: "app"},
+		TranslateOptions{ManifestPath: nextPath, AppName: "app"},
+	)
+	if err != nil {
+		t.Fatalf("TranslateDiff failed: %v", err)
+	}
+
+	if len(result.RemovedResources) != 1 || result.RemovedResources[0] != "worker" {
+		t.Errorf("expected RemovedResources to be [\"worker\"], got %v", result.RemovedResources)
+	}
+	if len(result.AddedResources) != 0 {
+		t.Errorf("expected no added resources, got %v", result.AddedResources)
+	}
+}
+
+func TestTranslateDiff_ChangesPort(t *testing.T) {
+	t.Parallel()
+
+	prevPath := writeDiffManifest(t, `{"resources":{
+		"api": {"type": "container.v0", "image": "myapp/api:latest",
+			"bindings": {"http": {"scheme": "http", "protocol": "tcp", "port": 8080, "targetPort": 8080}}}
+	}}`)
+	nextPath := writeDiffManifest(t, `{"resources":{
+		"api": {"type": "container.v0", "image": "myapp/api:latest",
+			"bindings": {"http": {"scheme": "http", "protocol": "tcp", "port": 9090, "targetPort": 9090}}}
+	}}`)
+
+	result, err := TranslateDiff(
+		TranslateOptions{ManifestPath: prevPath, AppName: "app"},
+		TranslateOptions{ManifestPath: nextPath, AppName: "app"},
+	)
+	if err != nil {
+		t.Fatalf("TranslateDiff failed: %v", err)
+	}
+
+	changes, ok := result.ModifiedResources["api"]
+	if !ok {
+		t.Fatalf("expected \"api\" to be reported as modified, got %v", result.ModifiedResources)
+	}
+
+	foundPortChange := false
+	for _, change := range changes {
+		if change.Path == "/bindings/http/port" {
+			foundPortChange = true
+			if change.OldValue != float64(8080) || change.NewValue != float64(9090) {
+				t.Errorf("expected port change 8080 -> 9090, got %v -> %v", change.OldValue, change.NewValue)
+			}
+		}
+	}
+	if !foundPortChange {
+		t.Errorf("expected a /bindings/http/port change, got %+v", changes)
+	}
+}
+
+func TestTranslateDiff_DetectsRenameViaSharedConnectionTarget(t *testing.T) {
+	t.Parallel()
+
+	prevPath := writeDiffManifest(t, `{"resources":{
+		"cache": {"type": "container.v0", "image": "redis:7.2"},
+		"api": {"type": "container.v0", "image": "myapp/api:latest",
+			"env": {"REDIS_URL": "{cache.connectionString}"}}
+	}}`)
+	nextPath := writeDiffManifest(t, `{"resources":{
+		"rediscache": {"type": "container.v0", "image": "redis:7.2"},
+		"api": {"type": "container.v0", "image": "myapp/api:latest",
+			"env": {"REDIS_URL": "{rediscache.connectionString}"}}
+	}}`)
+
+	result, err := TranslateDiff(
+		TranslateOptions{ManifestPath: prevPath, AppName: "app"},
+		TranslateOptions{ManifestPath: nextPath, AppName: "app"},
+	)
+	if err != nil {
+		t.Fatalf("TranslateDiff failed: %v", err)
+	}
+
+	for _, name := range result.AddedResources {
+		if name == "rediscache" {
+			t.Error("expected the renamed resource not to appear in AddedResources")
+		}
+	}
+	for _, name := range result.RemovedResources {
+		if name == "cache" {
+			t.Error("expected the renamed resource not to appear in RemovedResources")
+		}
+	}
+
+	changes, ok := result.ModifiedResources["rediscache"]
+	if !ok {
+		t.Fatalf("expected the rename to surface as a modification of \"rediscache\", got %v", result.ModifiedResources)
+	}
+
+	if changes[0].Path != "/name" || changes[0].OldValue != "cache" || changes[0].NewValue != "rediscache" {
+		t.Errorf("expected a leading /name change from \"cache\" to \"rediscache\", got %+v", changes[0])
+	}
+}
+
+func TestTranslateDiff_NoChangesWhenManifestsIdentical(t *testing.T) {
+	t.Parallel()
+
+	manifestPath := writeDiffManifest(t, `{"resources":{
+		"api": {"type": "container.v0", "image": "myapp/api:latest"}
+	}}`)
+
+	result, err := TranslateDiff(
+		TranslateOptions{ManifestPath: manifestPath, AppName: "app"},
+		TranslateOptions{ManifestPath: manifestPath, AppName: "app"},
+	)
+	if err != nil {
+		t.Fatalf("TranslateDiff failed: %v", err)
+	}
+
+	if len(result.AddedResources) != 0 || len(result.RemovedResources) != 0 || len(result.ModifiedResources) != 0 {
+		t.Errorf("expected no differences, got added=%v removed=%v modified=%v", result.AddedResources, result.RemovedResources, result.ModifiedResources)
+	}
+	if result.UnifiedDiff != "" {
+		t.Errorf("expected an empty unified diff, got %q", result.UnifiedDiff)
+	}
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// bicepResourceDeclPattern matches the first line of a Bicep resource or param declaration
+// emitted by emit(), capturing its Bicep identifier.
+var bicepResourceDeclPattern = regexp.MustCompile(`^(?:resource|param)\s+(\w+)\b`)
+
+// detectDrift compares bicep, the freshly rendered output, against app.bicep already on
+// disk under ctx.config.existingOutputDir. It returns one whole-file DriftEntry (Added if
+// nothing exists there yet, Modified with a unified diff otherwise), followed by one
+// DriftEntry per resource block that differs, so a caller can tell at a glance which
+// manifest resource a hunk belongs to. Returns nil, nil when the two are identical.
+func detectDrift(ctx *translationContext, bicep string) ([]DriftEntry, error) {
+	path := filepath.Join(ctx.config.existingOutputDir, "app.bicep")
+
+	existing, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read existing output %q: %w", path, err)
+		}
+
+		return []DriftEntry{{Path: "app.bicep", Kind: DriftAdded}}, nil
+	}
+
+	if string(existing) == bicep {
+		return nil, nil
+	}
+
+	unified, err := unifiedDiff("app.bicep", string(existing), bicep)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []DriftEntry{{
+		Path:        "app.bicep",
+		Kind:        DriftModified,
+		UnifiedDiff: unified,
+	}}
+
+	resourceEntries, err := driftByResource(ctx, string(existing), bicep)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(entries, resourceEntries...), nil
+}
+
+// driftByResource re-diffs existing and rendered on a per-resource-block basis (blocks are
+// separated by the blank line emit()'s normalizeBlankLines leaves between them), returning
+// one DriftEntry per block whose text differs, in sorted-identifier order for deterministic
+// output. ResourceName is resolved by matching each block's Bicep identifier against
+// ctx.resources.
+func driftByResource(ctx *translationContext, existing, rendered string) ([]DriftEntry, error) {
+	existingBlocks := bicepBlocksByIdentifier(existing)
+	renderedBlocks := bicepBlocksByIdentifier(rendered)
+
+	identToName := make(map[string]string, len(ctx.resources))
+	for name, res := range ctx.resources {
+		identToName[res.BicepIdentifier] = name
+	}
+
+	identSet := make(map[string]bool, len(existingBlocks)+len(renderedBlocks))
+	for ident := range existingBlocks {
+		identSet[ident] = true
+	}
+	for ident := range renderedBlocks {
+		identSet[ident] = true
+	}
+
+	idents := make([]string, 0, len(identSet))
+	for ident := range identSet {
+		idents = append(idents, ident)
+	}
+	sort.Strings(idents)
+
+	var entries []DriftEntry
+	for _, ident := range idents {
+		oldBlock, hadOld := existingBlocks[ident]
+		newBlock, hasNew := renderedBlocks[ident]
+
+		if oldBlock == newBlock {
+			continue
+		}
+
+		kind := DriftModified
+		switch {
+		case !hadOld:
+			kind = DriftAdded
+		case !hasNew:
+			kind = DriftRemoved
+		}
+
+		unified, err := unifiedDiff(ident, oldBlock, newBlock)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, DriftEntry{
+			Path:         "app.bicep",
+			Kind:         kind,
+			UnifiedDiff:  unified,
+			ResourceName: identToName[ident],
+		})
+	}
+
+	return entries, nil
+}
+
+// bicepBlocksByIdentifier splits rendered Bicep text into a map of Bicep identifier to
+// block text, one entry per resource or param declaration found. The preamble and any
+// block with no recognizable declaration (e.g. a leading comment) are ignored.
+func bicepBlocksByIdentifier(text string) map[string]string {
+	blocks := make(map[string]string)
+
+	for _, block := range strings.Split(text, "\n\n") {
+		firstLine := strings.TrimLeft(block, "\n")
+		if i := strings.IndexByte(firstLine, '\n'); i >= 0 {
+			firstLine = firstLine[:i]
+		}
+
+		match := bicepResourceDeclPattern.FindStringSubmatch(firstLine)
+		if match == nil {
+			continue
+		}
+
+		blocks[match[1]] = block
+	}
+
+	return blocks
+}
+
+// unifiedDiff renders a deterministic unified diff of a against b, labeled with name on
+// both sides.
+func unifiedDiff(name, a, b string) (string, error) {
+	diff := difflib.UnifiedDiff{
+		A:        difflib.SplitLines(a),
+		B:        difflib.SplitLines(b),
+		FromFile: name,
+		ToFile:   name,
+		Context:  3,
+	}
+
+	unified, err := difflib.GetUnifiedDiffString(diff)
+	if err != nil {
+		return "", fmt.Errorf("failed to diff %q: %w", name, err)
+	}
+
+	return unified, nil
+}
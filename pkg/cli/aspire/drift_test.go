@@ -0,0 +1,159 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSimpleManifest(t *testing.T, tmpDir string) string {
+	t.Helper()
+
+	manifestPath := filepath.Join(tmpDir, "aspire-manifest.json")
+	content := `{"resources":{"api":{"type":"container.v0","image":"myapp/api:latest"}}}`
+	if err := os.WriteFile(manifestPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return manifestPath
+}
+
+func TestTranslate_DriftModeAddedWhenNothingOnDisk(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	manifestPath := writeSimpleManifest(t, tmpDir)
+
+	result, err := Translate(TranslateOptions{
+		ManifestPath:      manifestPath,
+		AppName:           "app",
+		Mode:              ModeDrift,
+		ExistingOutputDir: filepath.Join(tmpDir, "out"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Drift) != 1 {
+		t.Fatalf("expected 1 drift entry, got %d: %+v", len(result.Drift), result.Drift)
+	}
+
+	if result.Drift[0].Kind != DriftAdded {
+		t.Errorf("expected DriftAdded, got %q", result.Drift[0].Kind)
+	}
+}
+
+func TestTranslate_DriftModeNoDifference(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	manifestPath := writeSimpleManifest(t, tmpDir)
+
+	// Render once in ModeGenerate to get the exact text that would be on disk.
+	generated, err := Translate(TranslateOptions{
+		ManifestPath: manifestPath,
+		AppName:      "app",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	outDir := filepath.Join(tmpDir, "out")
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(outDir, "app.bicep"), []byte(generated.Bicep), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Translate(TranslateOptions{
+		ManifestPath:      manifestPath,
+		AppName:           "app",
+		Mode:              ModeDrift,
+		ExistingOutputDir: outDir,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Drift) != 0 {
+		t.Fatalf("expected no drift, got %+v", result.Drift)
+	}
+}
+
+func TestTranslate_DriftModeModifiedResourceBlock(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	manifestPath := writeSimpleManifest(t, tmpDir)
+
+	generated, err := Translate(TranslateOptions{
+		ManifestPath: manifestPath,
+		AppName:      "app",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Simulate a user having hand-edited the image tag in the previously generated file.
+	stale := strings.Replace(generated.Bicep, "myapp/api:latest", "myapp/api:old", 1)
+
+	outDir := filepath.Join(tmpDir, "out")
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(outDir, "app.bicep"), []byte(stale), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Translate(TranslateOptions{
+		ManifestPath:      manifestPath,
+		AppName:           "app",
+		Mode:              ModeDrift,
+		ExistingOutputDir: outDir,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Drift) < 2 {
+		t.Fatalf("expected a whole-file entry plus a per-resource entry, got %+v", result.Drift)
+	}
+
+	if result.Drift[0].Kind != DriftModified || result.Drift[0].ResourceName != "" {
+		t.Errorf("expected the first entry to be the whole-file diff, got %+v", result.Drift[0])
+	}
+
+	var found bool
+	for _, entry := range result.Drift[1:] {
+		if entry.ResourceName == "api" {
+			found = true
+			if entry.Kind != DriftModified {
+				t.Errorf("expected DriftModified for resource 'api', got %q", entry.Kind)
+			}
+			if !strings.Contains(entry.UnifiedDiff, "myapp/api") {
+				t.Errorf("expected the diff to mention the changed image, got %q", entry.UnifiedDiff)
+			}
+		}
+	}
+	if !found {
+		t.Error("expected a drift entry correlated back to resource 'api'")
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var builtinTemplatesFS embed.FS
+
+// templateKinds lists every resource-kind template the Bicep emitter loads, keyed by the
+// base file name (without ".tmpl") a TranslateOptions.TemplateDir override is matched
+// against. Each one has a built-in equivalent under templates/, so TemplateDir only needs
+// to supply the kinds a caller wants to customize.
+var templateKinds = []string{
+	"application",
+	"parameter",
+	"container",
+	"redis",
+	"sqldb",
+	"mongodb",
+	"rabbitmq",
+	"extender",
+	"secretstore",
+	"service",
+	"gateway",
+}
+
+// templateNameForKind returns the templateKinds entry that renders resources of kind, or
+// "" if kind has no per-kind template (e.g. KindValueResource and KindUnsupported never
+// reach emit).
+func templateNameForKind(kind ResourceKind) string {
+	switch kind {
+	case KindContainer:
+		return "container"
+	case KindRedisCache:
+		return "redis"
+	case KindSQLDB:
+		return "sqldb"
+	case KindMongoDB:
+		return "mongodb"
+	case KindRabbitMQ:
+		return "rabbitmq"
+	case KindExtender:
+		return "extender"
+	case KindSecretStore:
+		return "secretstore"
+	case KindService:
+		return "service"
+	case KindGateway:
+		return "gateway"
+	case KindApplication:
+		return "application"
+	case KindParameter:
+		return "parameter"
+	default:
+		return ""
+	}
+}
+
+// templateSet holds one parsed text/template.Template per templateKinds entry, sourced
+// from the built-in embedded defaults and overridden per-kind by files of the same name
+// under TranslateOptions.TemplateDir.
+type templateSet struct {
+	templates map[string]*template.Template
+}
+
+// loadTemplateSet builds the templateSet emit renders from, reading "<kind>.tmpl" out of
+// dir for any kind present there and falling back to the built-in template otherwise.
+// Passing an empty dir produces the all-builtin set, leaving behavior unchanged from
+// before TemplateDir existed.
+func loadTemplateSet(dir string) (*templateSet, error) {
+	ts := &templateSet{templates: make(map[string]*template.Template, len(templateKinds))}
+
+	for _, kind := range templateKinds {
+		text, err := templateText(dir, kind)
+		if err != nil {
+			return nil, err
+		}
+
+		tmpl, err := template.New(kind).Funcs(bicepFuncMap).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %q template: %w", kind, err)
+		}
+
+		ts.templates[kind] = tmpl
+	}
+
+	return ts, nil
+}
+
+// templateText returns the template source for kind, preferring "<kind>.tmpl" in dir
+// (when dir is set and the file exists) over the embedded built-in.
+func templateText(dir, kind string) (string, error) {
+	if dir != "" {
+		overridePath := filepath.Join(dir, kind+".tmpl")
+
+		text, err := os.ReadFile(overridePath)
+		if err == nil {
+			return string(text), nil
+		}
+		if !os.IsNotExist(err) {
+			return "", fmt.Errorf("failed to read template override %q: %w", overridePath, err)
+		}
+	}
+
+	text, err := builtinTemplatesFS.ReadFile("templates/" + kind + ".tmpl")
+	if err != nil {
+		return "", fmt.Errorf("failed to read built-in %q template: %w", kind, err)
+	}
+
+	return string(text), nil
+}
+
+// render executes the template registered for kind against data and returns the rendered
+// text. A field referenced by a template but absent from data surfaces here as a
+// text/template execution error (e.g. "can't evaluate field X in type Y"), so a custom
+// template that doesn't match the struct it's handed fails loudly instead of silently
+// dropping content.
+func (ts *templateSet) render(kind string, data any) (string, error) {
+	tmpl, ok := ts.templates[kind]
+	if !ok {
+		return "", fmt.Errorf("no template registered for kind %q", kind)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %q template: %w", kind, err)
+	}
+
+	return buf.String(), nil
+}
+
+// applicationTemplateData is the data passed to the "application" template: the
+// synthesized Applications.Core/applications RadiusResource, plus the two pieces of the
+// preamble that aren't tied to any single resource.
+type applicationTemplateData struct {
+	*RadiusResource
+
+	// HasPlatformVariants controls whether the targetPlatform parameter is declared.
+	HasPlatformVariants bool
+
+	// ParametersBlock is the already-rendered concatenation of the "parameter" template
+	// over every BicepParameter, spliced into the preamble as-is.
+	ParametersBlock string
+}
@@ -17,7 +17,6 @@ limitations under the License.
 package aspire
 
 import (
-	"bytes"
 	"fmt"
 	"sort"
 	"strings"
@@ -26,105 +25,130 @@ import (
 
 const apiVersion = "2023-10-01-preview"
 
-// bicepTemplateText is the Bicep template for the generated app.bicep file.
-const bicepTemplateText = `extension radius
-
-@description('The Radius environment ID')
-param environment string = '{{ .EnvironmentName }}'
-
-@description('The Radius application name')
-param application string = '{{ .AppName }}'
-{{ range .Parameters }}
-{{ if .Secure }}@secure()
-{{ end }}@description('{{ .Description }}')
-param {{ .Name }} string{{ if .DefaultValue }} = '{{ .DefaultValue }}'{{ end }}
-{{ end }}
-resource app 'Applications.Core/applications@{{ .APIVersion }}' = {
-  name: application
-  properties: {
-    environment: environment
-  }
+// bicepFuncMap is shared by every template in a templateSet; it's the full set of Bicep
+// block helpers any built-in or user-supplied template might call.
+var bicepFuncMap = template.FuncMap{
+	"portBlock":            renderPortBlock,
+	"envBlock":             renderEnvBlock,
+	"volumeBlock":          renderVolumeBlock,
+	"connectionBlock":      renderConnectionBlock,
+	"secretDataBlock":      renderSecretDataBlock,
+	"resourcesBlock":       renderResourcesBlock,
+	"probeBlock":           renderProbeBlock,
+	"securityContextBlock": renderSecurityContextBlock,
+	"platformImageExpr":    renderPlatformImageExpr,
+	"gatewayRoutesBlock":   renderGatewayRoutesBlock,
+	"nodePortBlock":        renderNodePortBlock,
 }
-{{ range .PortableResources }}
-resource {{ .BicepIdentifier }} '{{ .RadiusType }}@{{ $.APIVersion }}' = {
-  name: '{{ .RuntimeName }}'
-  properties: {
-    application: app.id
-    environment: environment
-    resourceProvisioning: 'recipe'
-    recipe: {
-      name: '{{ .PortableResource.RecipeName }}'
-    }
-  }
-}
-{{ end }}{{ range .Containers }}
-resource {{ .BicepIdentifier }} 'Applications.Core/containers@{{ $.APIVersion }}' = {
-  name: '{{ .RuntimeName }}'
-  properties: {
-    application: app.id
-    environment: environment
-    container: {
-      image: '{{ .Container.Image }}'{{ if .Container.Command }}
-      command: [{{ range $i, $c := .Container.Command }}{{ if $i }}, {{ end }}'{{ $c }}'{{ end }}]{{ end }}{{ if .Container.Args }}
-      args: [{{ range $i, $a := .Container.Args }}{{ if $i }}, {{ end }}'{{ $a }}'{{ end }}]{{ end }}{{ if .Container.Ports }}
-      ports: {{ portBlock .Container.Ports }}{{ end }}{{ if .Container.Env }}
-      env: {{ envBlock .Container.Env }}{{ end }}{{ if .Container.Volumes }}
-      volumes: {{ volumeBlock .Container.Volumes }}{{ end }}
-    }{{ if .Connections }}
-    connections: {{ connectionBlock .Connections }}{{ end }}
-  }
-}
-{{ end }}{{ range .Gateways }}
-resource {{ .BicepIdentifier }} 'Applications.Core/gateways@{{ $.APIVersion }}' = {
-  name: '{{ .RuntimeName }}'
-  properties: {
-    application: app.id
-    routes: [{{ range .Gateway.Routes }}
-      {
-        path: '{{ .Path }}'
-        destination: '{{ .Destination }}'
-      }{{ end }}
-    ]
-  }
-}
-{{ end }}`
 
 // bicepData is the data passed to the Bicep template.
 type bicepData struct {
-	EnvironmentName   string
-	AppName           string
-	APIVersion        string
-	Parameters        []BicepParameter
-	PortableResources []*RadiusResource
-	Containers        []*RadiusResource
-	Gateways          []*RadiusResource
+	EnvironmentName     string
+	AppName             string
+	APIVersion          string
+	Parameters          []BicepParameter
+	PortableResources   []*RadiusResource
+	Containers          []*RadiusResource
+	Extenders           []*RadiusResource
+	Services            []*RadiusResource
+	Gateways            []*RadiusResource
+	SecretStore         *RadiusResource
+	HasPlatformVariants bool
 }
 
-// emit renders the Bicep template from the translation context.
+// emit renders the Bicep output from the translation context, one per-resource-kind
+// template at a time (see loadTemplateSet), in the same deterministic order
+// prepareBicepData sorts resources into: parameters, application, portable resources,
+// containers, extenders, secret store, services, gateways.
 func emit(ctx *translationContext) (string, error) {
 	data := prepareBicepData(ctx)
 
-	funcMap := template.FuncMap{
-		"portBlock":       renderPortBlock,
-		"envBlock":        renderEnvBlock,
-		"volumeBlock":     renderVolumeBlock,
-		"connectionBlock": renderConnectionBlock,
+	ts, err := loadTemplateSet(ctx.config.templateDir)
+	if err != nil {
+		return "", err
+	}
+
+	var paramsBlock strings.Builder
+	for _, param := range data.Parameters {
+		rendered, err := ts.render("parameter", param)
+		if err != nil {
+			return "", err
+		}
+
+		paramsBlock.WriteString(rendered)
 	}
 
-	tmpl, err := template.New("bicep").Funcs(funcMap).Parse(bicepTemplateText)
+	var out strings.Builder
+
+	appData := applicationTemplateData{
+		RadiusResource:      synthesizeApplication(data.AppName, data.EnvironmentName),
+		HasPlatformVariants: data.HasPlatformVariants,
+		ParametersBlock:     paramsBlock.String(),
+	}
+
+	rendered, err := ts.render("application", appData)
 	if err != nil {
-		return "", fmt.Errorf("failed to render Bicep template: %w", err)
+		return "", err
 	}
 
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
-		return "", fmt.Errorf("failed to render Bicep template: %w", err)
+	out.WriteString(rendered)
+
+	for _, resource := range data.PortableResources {
+		rendered, err := ts.render(templateNameForKind(resource.Kind), resource)
+		if err != nil {
+			return "", err
+		}
+
+		out.WriteString(rendered)
 	}
 
-	// Clean up extra blank lines (normalize to max 1 blank line between sections).
-	result := normalizeBlankLines(buf.String())
+	for _, resource := range data.Containers {
+		rendered, err := ts.render("container", resource)
+		if err != nil {
+			return "", err
+		}
+
+		out.WriteString(rendered)
+	}
+
+	for _, resource := range data.Extenders {
+		rendered, err := ts.render("extender", resource)
+		if err != nil {
+			return "", err
+		}
+
+		out.WriteString(rendered)
+	}
+
+	if data.SecretStore != nil {
+		rendered, err := ts.render("secretstore", data.SecretStore)
+		if err != nil {
+			return "", err
+		}
 
-	return result, nil
+		out.WriteString(rendered)
+	}
+
+	for _, resource := range data.Services {
+		rendered, err := ts.render("service", resource)
+		if err != nil {
+			return "", err
+		}
+
+		out.WriteString(rendered)
+	}
+
+	for _, resource := range data.Gateways {
+		rendered, err := ts.render("gateway", resource)
+		if err != nil {
+			return "", err
+		}
+
+		out.WriteString(rendered)
+	}
+
+	// Clean up extra blank lines (normalize to max 1 blank line between sections).
+	return normalizeBlankLines(out.String()), nil
 }
 
 // prepareBicepData extracts and sorts resources from the translation context into bicepData.
@@ -142,15 +166,21 @@ func prepareBicepData(ctx *translationContext) *bicepData {
 	})
 
 	// Collect and sort resources by kind.
-	var portableResources, containers, gateways []*RadiusResource
+	var portableResources, containers, extenders, services, gateways []*RadiusResource
 	for _, resource := range ctx.resources {
 		switch {
 		case resource.Kind.IsPortableResource():
 			portableResources = append(portableResources, resource)
 		case resource.Kind == KindContainer:
 			containers = append(containers, resource)
+		case resource.Kind == KindExtender:
+			extenders = append(extenders, resource)
+		case resource.Kind == KindService:
+			services = append(services, resource)
 		case resource.Kind == KindGateway:
 			gateways = append(gateways, resource)
+		case resource.Kind == KindSecretStore:
+			data.SecretStore = resource
 		}
 	}
 
@@ -161,14 +191,29 @@ func prepareBicepData(ctx *translationContext) *bicepData {
 	sort.Slice(containers, func(i, j int) bool {
 		return containers[i].BicepIdentifier < containers[j].BicepIdentifier
 	})
+	sort.Slice(extenders, func(i, j int) bool {
+		return extenders[i].BicepIdentifier < extenders[j].BicepIdentifier
+	})
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].BicepIdentifier < services[j].BicepIdentifier
+	})
 	sort.Slice(gateways, func(i, j int) bool {
 		return gateways[i].BicepIdentifier < gateways[j].BicepIdentifier
 	})
 
 	data.PortableResources = portableResources
 	data.Containers = containers
+	data.Extenders = extenders
+	data.Services = services
 	data.Gateways = gateways
 
+	for _, container := range containers {
+		if container.Container != nil && len(container.Container.PlatformVariants) > 0 {
+			data.HasPlatformVariants = true
+			break
+		}
+	}
+
 	return data
 }
 
@@ -178,26 +223,29 @@ func renderPortBlock(ports map[string]PortSpec) string {
 		return "{}"
 	}
 
-	var buf bytes.Buffer
-	buf.WriteString("{\n")
+	obj := bicepObject{}
 
-	keys := sortedKeys(ports)
-	for _, name := range keys {
+	for _, name := range sortedKeys(ports) {
 		port := ports[name]
-		buf.WriteString(fmt.Sprintf("        %s: {\n", name))
-		buf.WriteString(fmt.Sprintf("          containerPort: %d\n", port.ContainerPort))
+
+		portObj := bicepObject{Fields: []bicepField{
+			{Key: "containerPort", Value: bicepNumber(port.ContainerPort)},
+		}}
+
 		if port.Protocol != "" && port.Protocol != "TCP" {
-			buf.WriteString(fmt.Sprintf("          protocol: '%s'\n", port.Protocol))
+			portObj.Fields = append(portObj.Fields, bicepField{Key: "protocol", Value: bicepLiteral(port.Protocol)})
 		}
 		if port.Scheme != "" {
-			buf.WriteString(fmt.Sprintf("          scheme: '%s'\n", port.Scheme))
+			portObj.Fields = append(portObj.Fields, bicepField{Key: "scheme", Value: bicepLiteral(port.Scheme)})
+		}
+		if port.HostPort != 0 {
+			portObj.Fields = append(portObj.Fields, bicepField{Key: "hostPort", Value: bicepNumber(port.HostPort)})
 		}
-		buf.WriteString("        }\n")
-	}
 
-	buf.WriteString("      }")
+		obj.Fields = append(obj.Fields, bicepField{Key: name, Value: portObj})
+	}
 
-	return buf.String()
+	return obj.print(6)
 }
 
 // renderEnvBlock renders a Bicep env block.
@@ -206,26 +254,57 @@ func renderEnvBlock(env map[string]EnvVarSpec) string {
 		return "{}"
 	}
 
-	var buf bytes.Buffer
-	buf.WriteString("{\n")
+	obj := bicepObject{}
 
-	keys := sortedKeysEnv(env)
-	for _, name := range keys {
+	for _, name := range sortedKeysEnv(env) {
 		spec := env[name]
-		if spec.IsBicepInterpolation {
-			buf.WriteString(fmt.Sprintf("        %s: {\n", name))
-			buf.WriteString(fmt.Sprintf("          value: '%s'\n", spec.Value))
-			buf.WriteString("        }\n")
-		} else {
-			buf.WriteString(fmt.Sprintf("        %s: {\n", name))
-			buf.WriteString(fmt.Sprintf("          value: '%s'\n", spec.Value))
-			buf.WriteString("        }\n")
+
+		if spec.SecretRef != nil {
+			envObj := bicepObject{Fields: []bicepField{
+				{Key: "valueFrom", Value: bicepObject{Fields: []bicepField{
+					{Key: "secretRef", Value: bicepObject{Fields: []bicepField{
+						{Key: "source", Value: bicepRaw(spec.SecretRef.SecretStoreIdentifier + ".id")},
+						{Key: "key", Value: bicepLiteral(spec.SecretRef.Key)},
+					}}},
+				}}},
+			}}
+
+			obj.Fields = append(obj.Fields, bicepField{Key: name, Value: envObj})
+			continue
 		}
+
+		envObj := bicepObject{Fields: []bicepField{
+			{Key: "value", Value: bicepLiteral(spec.Value)},
+		}}
+
+		obj.Fields = append(obj.Fields, bicepField{Key: name, Value: envObj})
 	}
 
-	buf.WriteString("      }")
+	return obj.print(6)
+}
+
+// renderSecretDataBlock renders a secretStores resource's data block. Keys with no default
+// value are emitted with an empty string, to be populated by the user after deploy.
+func renderSecretDataBlock(keys map[string]string) string {
+	if len(keys) == 0 {
+		return "{}"
+	}
+
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	obj := bicepObject{}
+	for _, name := range names {
+		obj.Fields = append(obj.Fields, bicepField{Key: name, Value: bicepObject{Fields: []bicepField{
+			{Key: "value", Value: bicepLiteral(keys[name])},
+		}}})
+	}
 
-	return buf.String()
+	return obj.print(4)
 }
 
 // renderVolumeBlock renders a Bicep volumes block.
@@ -234,24 +313,98 @@ func renderVolumeBlock(volumes map[string]VolumeSpec) string {
 		return "{}"
 	}
 
-	var buf bytes.Buffer
-	buf.WriteString("{\n")
+	obj := bicepObject{}
 
-	keys := sortedKeysVolume(volumes)
-	for _, name := range keys {
+	for _, name := range sortedKeysVolume(volumes) {
 		vol := volumes[name]
-		buf.WriteString(fmt.Sprintf("        %s: {\n", name))
-		buf.WriteString(fmt.Sprintf("          kind: '%s'\n", vol.Kind))
-		buf.WriteString(fmt.Sprintf("          mountPath: '%s'\n", vol.MountPath))
+
+		volObj := bicepObject{Fields: []bicepField{
+			{Key: "kind", Value: bicepLiteral(vol.Kind)},
+			{Key: "mountPath", Value: bicepLiteral(vol.MountPath)},
+		}}
+
 		if vol.ReadOnly {
-			buf.WriteString("          readOnly: true\n")
+			volObj.Fields = append(volObj.Fields, bicepField{Key: "readOnly", Value: bicepBool(true)})
 		}
-		buf.WriteString("        }\n")
+
+		obj.Fields = append(obj.Fields, bicepField{Key: name, Value: volObj})
+	}
+
+	return obj.print(6)
+}
+
+// renderResourcesBlock renders a container's compute resource requests block.
+func renderResourcesBlock(resources *ContainerResourcesSpec) string {
+	requests := bicepObject{}
+
+	if resources.CPU != "" {
+		requests.Fields = append(requests.Fields, bicepField{Key: "cpu", Value: bicepLiteral(resources.CPU)})
+	}
+	if resources.Memory != "" {
+		requests.Fields = append(requests.Fields, bicepField{Key: "memory", Value: bicepLiteral(resources.Memory)})
+	}
+
+	obj := bicepObject{Fields: []bicepField{{Key: "requests", Value: requests}}}
+
+	return obj.print(6)
+}
+
+// renderProbeBlock renders a Bicep health probe block.
+func renderProbeBlock(probe *ProbeSpec) string {
+	obj := bicepObject{Fields: []bicepField{
+		{Key: "kind", Value: bicepLiteral(probe.Kind)},
+	}}
+
+	if len(probe.Command) > 0 {
+		obj.Fields = append(obj.Fields, bicepField{Key: "command", Value: bicepLiteralArray(probe.Command)})
+	}
+	if probe.Path != "" {
+		obj.Fields = append(obj.Fields, bicepField{Key: "path", Value: bicepLiteral(probe.Path)})
+	}
+	if probe.ContainerPort > 0 {
+		obj.Fields = append(obj.Fields, bicepField{Key: "containerPort", Value: bicepNumber(probe.ContainerPort)})
+	}
+	if probe.InitialDelaySeconds > 0 {
+		obj.Fields = append(obj.Fields, bicepField{Key: "initialDelaySeconds", Value: bicepNumber(probe.InitialDelaySeconds)})
 	}
+	if probe.PeriodSeconds > 0 {
+		obj.Fields = append(obj.Fields, bicepField{Key: "periodSeconds", Value: bicepNumber(probe.PeriodSeconds)})
+	}
+	if probe.TimeoutSeconds > 0 {
+		obj.Fields = append(obj.Fields, bicepField{Key: "timeoutSeconds", Value: bicepNumber(probe.TimeoutSeconds)})
+	}
+	if probe.FailureThreshold > 0 {
+		obj.Fields = append(obj.Fields, bicepField{Key: "failureThreshold", Value: bicepNumber(probe.FailureThreshold)})
+	}
+
+	return obj.print(6)
+}
 
-	buf.WriteString("      }")
+// renderSecurityContextBlock renders a container's Linux security context block.
+func renderSecurityContextBlock(sc *SecurityContextSpec) string {
+	obj := bicepObject{}
 
-	return buf.String()
+	if sc.RunAsUser != "" {
+		obj.Fields = append(obj.Fields, bicepField{Key: "runAsUser", Value: bicepLiteral(sc.RunAsUser)})
+	}
+	if len(sc.CapAdd) > 0 {
+		obj.Fields = append(obj.Fields, bicepField{Key: "capAdd", Value: bicepLiteralArray(sc.CapAdd)})
+	}
+	if len(sc.SecurityOpt) > 0 {
+		obj.Fields = append(obj.Fields, bicepField{Key: "securityOpt", Value: bicepLiteralArray(sc.SecurityOpt)})
+	}
+	if len(sc.Sysctls) > 0 {
+		sysctls := bicepObject{}
+		for _, key := range sortedKeysString(sc.Sysctls) {
+			sysctls.Fields = append(sysctls.Fields, bicepField{Key: key, Value: bicepLiteral(sc.Sysctls[key])})
+		}
+		obj.Fields = append(obj.Fields, bicepField{Key: "sysctls", Value: sysctls})
+	}
+	if len(sc.Ulimits) > 0 {
+		obj.Fields = append(obj.Fields, bicepField{Key: "ulimits", Value: bicepLiteralArray(sc.Ulimits)})
+	}
+
+	return obj.print(6)
 }
 
 // renderConnectionBlock renders a Bicep connections block.
@@ -260,24 +413,125 @@ func renderConnectionBlock(connections map[string]ConnectionSpec) string {
 		return "{}"
 	}
 
-	var buf bytes.Buffer
-	buf.WriteString("{\n")
+	obj := bicepObject{}
 
-	keys := sortedKeysConn(connections)
-	for _, name := range keys {
+	for _, name := range sortedKeysConn(connections) {
 		conn := connections[name]
-		buf.WriteString(fmt.Sprintf("      %s: {\n", name))
+
+		var source bicepValue = bicepLiteral(conn.Source)
 		if conn.IsBicepReference {
-			buf.WriteString(fmt.Sprintf("        source: %s\n", conn.Source))
+			source = bicepRaw(conn.Source)
+		}
+
+		obj.Fields = append(obj.Fields, bicepField{Key: name, Value: bicepObject{Fields: []bicepField{
+			{Key: "source", Value: source},
+		}}})
+	}
+
+	return obj.print(4)
+}
+
+// renderGatewayRoutesBlock renders a gateway resource's routes array, one object per
+// route in Routes order (already sorted deterministically by synthesizeGateway).
+func renderGatewayRoutesBlock(routes []GatewayRouteSpec) string {
+	items := make([]bicepObject, 0, len(routes))
+
+	for _, route := range routes {
+		obj := bicepObject{Fields: []bicepField{
+			{Key: "path", Value: bicepLiteral(route.Path)},
+		}}
+
+		if route.Hostname != "" {
+			obj.Fields = append(obj.Fields, bicepField{Key: "hostname", Value: bicepLiteral(route.Hostname)})
+		}
+
+		if route.Redirect != nil {
+			obj.Fields = append(obj.Fields, bicepField{Key: "redirect", Value: bicepObject{Fields: []bicepField{
+				{Key: "url", Value: bicepLiteral(route.Redirect.Destination)},
+				{Key: "statusCode", Value: bicepNumber(route.Redirect.StatusCode)},
+			}}})
 		} else {
-			buf.WriteString(fmt.Sprintf("        source: '%s'\n", conn.Source))
+			obj.Fields = append(obj.Fields, bicepField{Key: "destination", Value: bicepLiteral(route.Destination)})
+		}
+
+		if route.TLS != nil {
+			obj.Fields = append(obj.Fields, bicepField{Key: "tls", Value: bicepObject{Fields: []bicepField{
+				{Key: "certificateFrom", Value: bicepLiteral(route.TLS.CertificateFrom)},
+			}}})
+		}
+
+		if len(route.SourceRanges) > 0 {
+			obj.Fields = append(obj.Fields, bicepField{Key: "sourceRanges", Value: bicepLiteralArray(route.SourceRanges)})
+		}
+
+		items = append(items, obj)
+	}
+
+	return bicepObjectArray{Items: items}.print(4)
+}
+
+// renderNodePortBlock renders a NodePort-style service's ports object, one entry per
+// binding name, sorted for deterministic output.
+func renderNodePortBlock(ports map[string]NodePortSpec) string {
+	if len(ports) == 0 {
+		return "{}"
+	}
+
+	obj := bicepObject{}
+
+	for _, name := range sortedKeysNodePort(ports) {
+		port := ports[name]
+
+		portObj := bicepObject{Fields: []bicepField{
+			{Key: "containerPort", Value: bicepNumber(port.ContainerPort)},
+			{Key: "nodePort", Value: bicepNumber(port.NodePort)},
+		}}
+
+		if port.Protocol != "" && port.Protocol != "TCP" {
+			portObj.Fields = append(portObj.Fields, bicepField{Key: "protocol", Value: bicepLiteral(port.Protocol)})
 		}
-		buf.WriteString("      }\n")
+
+		obj.Fields = append(obj.Fields, bicepField{Key: name, Value: portObj})
 	}
 
-	buf.WriteString("    }")
+	return obj.print(6)
+}
+
+// renderPlatformImageExpr renders a Bicep conditional expression that selects the image
+// digest matching the targetPlatform parameter from a container's PlatformVariants,
+// falling back to the first platform (sorted) when targetPlatform matches none of them.
+func renderPlatformImageExpr(c *ContainerSpec) string {
+	repo := imageRepository(c.Image)
 
-	return buf.String()
+	variants := make([]ImageVariant, len(c.PlatformVariants))
+	copy(variants, c.PlatformVariants)
+	sort.Slice(variants, func(i, j int) bool {
+		return variants[i].Platform < variants[j].Platform
+	})
+
+	expr := fmt.Sprintf("'%s@%s'", repo, variants[0].Digest)
+	for i := len(variants) - 1; i >= 1; i-- {
+		v := variants[i]
+		expr = fmt.Sprintf("targetPlatform == '%s' ? '%s@%s' : %s", v.Platform, repo, v.Digest, expr)
+	}
+
+	return expr
+}
+
+// imageRepository strips the tag/digest from an image reference, returning just the
+// domain and path, e.g. "myregistry.io/library/postgres". Falls back to the original
+// string when image fails to parse.
+func imageRepository(image string) string {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return image
+	}
+
+	if ref.Domain == "" {
+		return ref.Path
+	}
+
+	return ref.Domain + "/" + ref.Path
 }
 
 // normalizeBlankLines reduces multiple consecutive blank lines to a single blank line.
@@ -351,3 +605,25 @@ func sortedKeysConn(m map[string]ConnectionSpec) []string {
 
 	return keys
 }
+
+func sortedKeysNodePort(m map[string]NodePortSpec) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func sortedKeysString(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
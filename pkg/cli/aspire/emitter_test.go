@@ -14,26 +14,12 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package aspire
-/*
-Copyright 2023 The Radius Authors.
-
-Licensed under the Apache License, Version 2.0 (the "License");
-you may not use this file except in compliance with the License.
-You may obtain a copy of the License at
-
-    http://www.apache.org/licenses/LICENSE-2.0
-
-Unless required by applicable law or agreed to in writing, software
-distributed under the License is distributed on an "AS IS" BASIS,
-WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-See the License for the specific language governing permissions and
-limitations under the License.
-*/
-
 package aspire
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -51,6 +37,7 @@ func TestEmit_MinimalApplication(t *testing.T) {
 				BicepIdentifier: "api",
 				RuntimeName:     "api",
 				RadiusType:      "Applications.Core/containers",
+				APIVersion:      apiVersion,
 				Kind:            KindContainer,
 				Container: &ContainerSpec{
 					Image: "myapp/api:latest",
@@ -147,3 +134,244 @@ func TestEmit_WithConnections(t *testing.T) {
 		t.Error("expected Bicep reference source")
 	}
 }
+
+func TestEmit_ContainerWithImageParam(t *testing.T) {
+	t.Parallel()
+
+	ctx := &translationContext{
+		config: &translationConfig{
+			appName:         "app",
+			environmentName: "default",
+		},
+		parameters: []BicepParameter{
+			{Name: "apiImage", Type: "string", Description: "Container image for project resource \"api\""},
+		},
+		resources: map[string]*RadiusResource{
+			"api": {
+				BicepIdentifier: "api",
+				RuntimeName:     "api",
+				RadiusType:      "Applications.Core/containers",
+				Kind:            KindContainer,
+				Container: &ContainerSpec{
+					ImageParam: "apiImage",
+				},
+			},
+		},
+	}
+
+	result, err := emit(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(result, "param apiImage string") {
+		t.Error("expected apiImage parameter declaration in output")
+	}
+
+	if !strings.Contains(result, "image: apiImage") {
+		t.Error("expected unquoted image parameter reference in output")
+	}
+
+	if strings.Contains(result, "image: 'apiImage'") {
+		t.Error("expected image parameter reference to be unquoted")
+	}
+}
+
+func TestEmit_ContainerWithPlatformVariants(t *testing.T) {
+	t.Parallel()
+
+	ctx := &translationContext{
+		config: &translationConfig{
+			appName:         "app",
+			environmentName: "default",
+		},
+		resources: map[string]*RadiusResource{
+			"api": {
+				BicepIdentifier: "api",
+				RuntimeName:     "api",
+				RadiusType:      "Applications.Core/containers",
+				Kind:            KindContainer,
+				Container: &ContainerSpec{
+					Image: "myapp/api:latest",
+					PlatformVariants: []ImageVariant{
+						{Platform: "linux/amd64", Digest: "sha256:amd64digest", Size: 123},
+						{Platform: "linux/arm64", Digest: "sha256:arm64digest", Size: 456},
+					},
+				},
+			},
+		},
+	}
+
+	result, err := emit(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(result, "param targetPlatform string = 'linux/amd64'") {
+		t.Error("expected targetPlatform parameter declaration in output")
+	}
+
+	if !strings.Contains(result, "targetPlatform == 'linux/arm64' ? 'myapp/api@sha256:arm64digest' : 'myapp/api@sha256:amd64digest'") {
+		t.Errorf("expected platform selection expression in output, got:\n%s", result)
+	}
+}
+
+func TestEmit_ContainerWithoutPlatformVariantsOmitsTargetPlatformParam(t *testing.T) {
+	t.Parallel()
+
+	ctx := &translationContext{
+		config: &translationConfig{
+			appName:         "app",
+			environmentName: "default",
+		},
+		resources: map[string]*RadiusResource{
+			"api": {
+				BicepIdentifier: "api",
+				RuntimeName:     "api",
+				RadiusType:      "Applications.Core/containers",
+				Kind:            KindContainer,
+				Container: &ContainerSpec{
+					Image: "myapp/api:latest",
+				},
+			},
+		},
+	}
+
+	result, err := emit(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Contains(result, "targetPlatform") {
+		t.Error("expected no targetPlatform parameter when no container has platform variants")
+	}
+}
+
+func TestEmit_CustomContainerTemplateOverridesBuiltin(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	customTemplate := "// CUSTOM-CONTAINER-TEMPLATE {{ .BicepIdentifier }}\nresource {{ .BicepIdentifier }} 'Applications.Core/containers@{{ .APIVersion }}' = {\n  name: '{{ .RuntimeName }}'\n}\n"
+
+	if err := os.WriteFile(filepath.Join(dir, "container.tmpl"), []byte(customTemplate), 0o644); err != nil {
+		t.Fatalf("failed to write custom template: %v", err)
+	}
+
+	ctx := &translationContext{
+		config: &translationConfig{
+			appName:         "app",
+			environmentName: "default",
+			templateDir:     dir,
+		},
+		resources: map[string]*RadiusResource{
+			"api": {
+				BicepIdentifier: "api",
+				RuntimeName:     "api",
+				RadiusType:      "Applications.Core/containers",
+				APIVersion:      apiVersion,
+				Kind:            KindContainer,
+				Container: &ContainerSpec{
+					Image: "myapp/api:latest",
+				},
+			},
+		},
+	}
+
+	result, err := emit(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(result, "// CUSTOM-CONTAINER-TEMPLATE api") {
+		t.Errorf("expected output rendered from the custom container.tmpl, got:\n%s", result)
+	}
+
+	// The built-in redis/gateway/application templates should still be used, since the
+	// override directory only supplies container.tmpl.
+	if !strings.Contains(result, "resource app 'Applications.Core/applications@") {
+		t.Error("expected built-in application template output to still be present")
+	}
+}
+
+func TestEmit_CustomTemplateReferencingUnknownFieldErrors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	badTemplate := "resource {{ .BicepIdentifier }} 'Applications.Core/containers@{{ .APIVersion }}' = {\n  name: '{{ .NotAField }}'\n}\n"
+
+	if err := os.WriteFile(filepath.Join(dir, "container.tmpl"), []byte(badTemplate), 0o644); err != nil {
+		t.Fatalf("failed to write custom template: %v", err)
+	}
+
+	ctx := &translationContext{
+		config: &translationConfig{
+			appName:         "app",
+			environmentName: "default",
+			templateDir:     dir,
+		},
+		resources: map[string]*RadiusResource{
+			"api": {
+				BicepIdentifier: "api",
+				RuntimeName:     "api",
+				RadiusType:      "Applications.Core/containers",
+				APIVersion:      apiVersion,
+				Kind:            KindContainer,
+				Container:       &ContainerSpec{Image: "myapp/api:latest"},
+			},
+		},
+	}
+
+	if _, err := emit(ctx); err == nil {
+		t.Error("expected an error from a template referencing a field not on the struct")
+	}
+}
+
+// TestEmit_DeterministicEnvAndConnectionOrder guards against Go's randomized map
+// iteration order leaking into the emitted Bicep: a container with many env vars and
+// connections must render byte-identical output across repeated emit() calls, since Go
+// randomizes the iteration order of the same maps anew on every range over them.
+func TestEmit_DeterministicEnvAndConnectionOrder(t *testing.T) {
+	t.Parallel()
+
+	env := make(map[string]EnvVarSpec, 12)
+	connections := make(map[string]ConnectionSpec, 12)
+	for i := 0; i < 12; i++ {
+		key := fmt.Sprintf("VAR_%02d", i)
+		env[key] = EnvVarSpec{Value: fmt.Sprintf("value-%02d", i)}
+		connections[fmt.Sprintf("service%02d", i)] = ConnectionSpec{Source: fmt.Sprintf("http://service%02d:8080", i)}
+	}
+
+	buildCtx := func() *translationContext {
+		return &translationContext{
+			config: &translationConfig{appName: "app", environmentName: "default"},
+			resources: map[string]*RadiusResource{
+				"api": {
+					BicepIdentifier: "api",
+					RuntimeName:     "api",
+					RadiusType:      "Applications.Core/containers",
+					APIVersion:      apiVersion,
+					Kind:            KindContainer,
+					Container:       &ContainerSpec{Image: "myapp/api:latest", Env: env},
+					Connections:     connections,
+				},
+			},
+		}
+	}
+
+	var first string
+	for i := 0; i < 100; i++ {
+		result, err := emit(buildCtx())
+		if err != nil {
+			t.Fatalf("unexpected error on iteration %d: %v", i, err)
+		}
+
+		if i == 0 {
+			first = result
+			continue
+		}
+
+		if result != first {
+			t.Fatalf("emit() produced non-deterministic output on iteration %d", i)
+		}
+	}
+}
@@ -16,7 +16,10 @@ limitations under the License.
 
 package aspire
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // identifierCollisionError is returned when two resource names produce the same
 // Bicep identifier after sanitization.
@@ -49,6 +52,16 @@ func (e *missingImageMappingError) Error() string {
 	return fmt.Sprintf("project resource %q requires an image mapping", e.resourceName)
 }
 
+// missingBuildSourceError is returned when a project resource has no buildable source path
+// (the manifest's "path" field) but BuildMode requires one.
+type missingBuildSourceError struct {
+	resourceName string
+}
+
+func (e *missingBuildSourceError) Error() string {
+	return fmt.Sprintf("project resource %q has no build source path", e.resourceName)
+}
+
 // unsupportedExpressionError is returned when an expression has unsupported syntax.
 type unsupportedExpressionError struct {
 	resourceName string
@@ -59,11 +72,125 @@ func (e *unsupportedExpressionError) Error() string {
 	return fmt.Sprintf("unsupported expression syntax in resource %q: %s", e.resourceName, e.expression)
 }
 
-// circularReferenceError is returned when a circular dependency is detected.
+// requiredExpressionError is returned when a "{ref:?message}" required expression
+// reference could not be resolved.
+type requiredExpressionError struct {
+	expression string
+	message    string
+	cause      error
+}
+
+func (e *requiredExpressionError) Error() string {
+	return fmt.Sprintf("required expression %s could not be resolved (%s): %v", e.expression, e.message, e.cause)
+}
+
+func (e *requiredExpressionError) Unwrap() error {
+	return e.cause
+}
+
+// gatewayRouteCollisionError is returned when two gateway routes would claim the same
+// hostname and path.
+type gatewayRouteCollisionError struct {
+	host          string
+	path          string
+	firstBinding  string
+	secondBinding string
+}
+
+func (e *gatewayRouteCollisionError) Error() string {
+	return fmt.Sprintf("gateway route collision: bindings %q and %q both claim host %q path %q", e.firstBinding, e.secondBinding, e.host, e.path)
+}
+
+// circularReferenceError is returned when detectCircularReferences finds one or more
+// strongly-connected components in the connectionString/value dependency graph. Each
+// cycle lists every resource in the component together with the specific edges
+// (resource -> resource, via which expression) that prove it's a cycle, so a user can
+// tell which reference to break without re-deriving the graph themselves.
 type circularReferenceError struct {
-	chain []string
+	cycles []circularReferenceCycle
+}
+
+// circularReferenceCycle is a single strongly-connected component of mutually dependent
+// resources, along with the dependency edges found among its members.
+type circularReferenceCycle struct {
+	resources []string
+	edges     []circularDepEdge
+}
+
+// circularDepEdge is one dependency edge within a circularReferenceCycle: resource
+// "from" depends on resource "to" because of the expression "via".
+type circularDepEdge struct {
+	from string
+	to   string
+	via  string
 }
 
 func (e *circularReferenceError) Error() string {
-	return fmt.Sprintf("circular reference detected: %s", fmt.Sprintf("%v", e.chain))
+	descriptions := make([]string, 0, len(e.cycles))
+
+	for _, cycle := range e.cycles {
+		edgeDescriptions := make([]string, 0, len(cycle.edges))
+		for _, edge := range cycle.edges {
+			edgeDescriptions = append(edgeDescriptions, fmt.Sprintf("%s -> %s (via %s)", edge.from, edge.to, edge.via))
+		}
+
+		descriptions = append(descriptions, fmt.Sprintf("[%s]: %s", strings.Join(cycle.resources, ", "), strings.Join(edgeDescriptions, ", ")))
+	}
+
+	return fmt.Sprintf("circular reference detected: %s", strings.Join(descriptions, "; "))
+}
+
+// unsupportedGatewayModeError is returned when TranslateOptions.GatewayMode is set to a
+// value synthesizeGateway doesn't recognize.
+type unsupportedGatewayModeError struct {
+	mode GatewayMode
+}
+
+func (e *unsupportedGatewayModeError) Error() string {
+	return fmt.Sprintf("unsupported gateway mode %q (expected %q, %q, or %q)", e.mode, GatewayModeIngress, GatewayModeNodePort, GatewayModeNone)
+}
+
+// schemaViolationError is returned per-violation when validateManifestSchema finds the
+// manifest doesn't conform to its declared Aspire JSON Schema.
+type schemaViolationError struct {
+	// path is the JSON pointer (e.g. "/resources/api/bindings/http/port") to the value
+	// that failed validation.
+	path string
+
+	// message is the schema validator's description of the failure (e.g. "got string,
+	// want integer"), naming the expected and actual value where the validator can tell.
+	message string
+}
+
+func (e *schemaViolationError) Error() string {
+	return fmt.Sprintf("manifest schema violation at %q: %s", e.path, e.message)
+}
+
+// connectionStringCycleError is returned when resolveConnectionString or
+// resolveValueReference is asked to resolve the same (sourceResource, targetResource)
+// edge it is already in the middle of resolving. detectCircularReferences should catch
+// every cycle before resolution starts; this is a backstop that turns any cycle it
+// misses into a translation error instead of unbounded recursion.
+type connectionStringCycleError struct {
+	sourceResource string
+	targetResource string
+}
+
+func (e *connectionStringCycleError) Error() string {
+	return fmt.Sprintf("circular reference detected while resolving %q's reference to %q's connection string", e.sourceResource, e.targetResource)
+}
+
+// overlayParseError is returned when a TranslateOptions.ManifestOverlays entry isn't
+// valid YAML/JSON or doesn't match ContainerOverlay's shape.
+type overlayParseError struct {
+	resourceName string
+	cause        error
+}
+
+func (e *overlayParseError) Error() string {
+	return fmt.Sprintf("manifest overlay for resource %q is invalid: %s", e.resourceName, e.cause)
+}
+
+func (e *overlayParseError) Unwrap() error {
+	return e.cause
 }
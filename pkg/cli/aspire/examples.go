@@ -0,0 +1,269 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ExampleOptions configures GenerateExamples.
+type ExampleOptions struct {
+	// Check, when true, does not write any files. Instead it re-runs translation for
+	// every example and compares the result against what's already on disk under
+	// outDir, returning an error describing every manifest/Bicep pair that has drifted.
+	Check bool
+}
+
+// exampleScenario is a minimal, hand-written Aspire manifest plus the TranslateOptions
+// needed to exercise one ResourceKind end-to-end through classify, mapResources, and emit.
+type exampleScenario struct {
+	// name is the file basename, e.g. "container" produces container.json/container.bicep.
+	name string
+
+	// kind is the ResourceKind this scenario is meant to demonstrate, purely documentation
+	// for maintainers reading examples.go; GenerateExamples does not assert the classified
+	// kind matches, since synthesized kinds (gateway/service/secretStore) never appear in
+	// kindMap themselves.
+	kind ResourceKind
+
+	manifest *AspireManifest
+	opts     TranslateOptions
+}
+
+// exampleScenarios returns one scenario per ResourceKind that GenerateExamples can reach
+// from a manifest. KindApplication has no corresponding manifest resource type (the
+// application resource is always synthesized), so it has no scenario here.
+func exampleScenarios() []exampleScenario {
+	return []exampleScenario{
+		{
+			name: "container",
+			kind: KindContainer,
+			manifest: &AspireManifest{Resources: map[string]ManifestResource{
+				"api": {
+					Type:  "container.v0",
+					Image: "myapp:latest",
+					Env:   map[string]string{"LOG_LEVEL": "info"},
+					Bindings: map[string]ManifestBinding{
+						"http": {Scheme: "http", Protocol: "tcp", Port: 8080, TargetPort: 8080},
+					},
+				},
+			}},
+		},
+		{
+			name: "rediscache",
+			kind: KindRedisCache,
+			manifest: &AspireManifest{Resources: map[string]ManifestResource{
+				"cache": {Type: "container.v0", Image: "redis:7.2"},
+			}},
+		},
+		{
+			name: "sqldatabase",
+			kind: KindSQLDB,
+			manifest: &AspireManifest{Resources: map[string]ManifestResource{
+				"db": {Type: "container.v0", Image: "postgres:16"},
+			}},
+		},
+		{
+			name: "mongodatabase",
+			kind: KindMongoDB,
+			manifest: &AspireManifest{Resources: map[string]ManifestResource{
+				"db": {Type: "container.v0", Image: "mongo:7"},
+			}},
+		},
+		{
+			name: "rabbitmq",
+			kind: KindRabbitMQ,
+			manifest: &AspireManifest{Resources: map[string]ManifestResource{
+				"queue": {Type: "container.v0", Image: "rabbitmq:3.13"},
+			}},
+		},
+		{
+			name: "parameter",
+			kind: KindParameter,
+			manifest: &AspireManifest{Resources: map[string]ManifestResource{
+				"environmentName": {Type: "parameter.v0", Value: "production"},
+			}},
+		},
+		{
+			name: "valueresource",
+			kind: KindValueResource,
+			manifest: &AspireManifest{Resources: map[string]ManifestResource{
+				"greeting": {Type: "value.v0", Value: "hello"},
+			}},
+		},
+		{
+			name: "gateway",
+			kind: KindGateway,
+			manifest: &AspireManifest{Resources: map[string]ManifestResource{
+				"frontend": {
+					Type:  "container.v0",
+					Image: "frontend:latest",
+					Bindings: map[string]ManifestBinding{
+						"http": {Scheme: "http", Protocol: "tcp", Port: 80, TargetPort: 8080, External: true},
+					},
+				},
+			}},
+		},
+		{
+			name: "service",
+			kind: KindService,
+			manifest: &AspireManifest{Resources: map[string]ManifestResource{
+				"frontend": {
+					Type:  "container.v0",
+					Image: "frontend:latest",
+					Bindings: map[string]ManifestBinding{
+						"http": {Scheme: "http", Protocol: "tcp", Port: 80, TargetPort: 8080, External: true},
+					},
+				},
+			}},
+			opts: TranslateOptions{GatewayMode: GatewayModeNodePort},
+		},
+		{
+			name: "secretstore",
+			kind: KindSecretStore,
+			manifest: &AspireManifest{Resources: map[string]ManifestResource{
+				"db-password": {
+					Type: "parameter.v0",
+					Inputs: map[string]ManifestParamInput{
+						"value": {Secret: true},
+					},
+				},
+			}},
+			opts: TranslateOptions{SecretBackend: SecretBackendRadiusSecretStore},
+		},
+		{
+			name: "extender",
+			kind: KindExtender,
+			manifest: &AspireManifest{Resources: map[string]ManifestResource{
+				"storage": {Type: "azure.storage.blob.v0"},
+			}},
+		},
+		{
+			name: "unsupported",
+			kind: KindUnsupported,
+			manifest: &AspireManifest{Resources: map[string]ManifestResource{
+				"mystery": {Type: "some-future-resource.v0"},
+			}},
+		},
+	}
+}
+
+// GenerateExamples writes a minimal Aspire manifest and its translated Bicep for every
+// ResourceKind reachable from a manifest to outDir/<name>.json and outDir/<name>.bicep.
+// Every example is produced by the real Translate pipeline (classify, mapResources, emit),
+// so the fixtures can never drift from the actual translation behavior.
+//
+// When opts.Check is true, GenerateExamples writes nothing. Instead it re-translates every
+// scenario and compares the result against the files already in outDir, returning an error
+// naming every example whose committed fixture no longer matches — the same drift-test
+// pattern as `go generate -check`.
+func GenerateExamples(outDir string, opts ExampleOptions) error {
+	if !opts.Check {
+		if err := os.MkdirAll(outDir, 0o755); err != nil {
+			return fmt.Errorf("failed to create output directory %q: %w", outDir, err)
+		}
+	}
+
+	var driftErrs []string
+
+	for _, scenario := range exampleScenarios() {
+		manifestJSON, err := json.MarshalIndent(scenario.manifest, "", "  ")
+		if err != nil {
+			return fmt.Errorf("example %q: failed to marshal manifest: %w", scenario.name, err)
+		}
+		manifestJSON = append(manifestJSON, '\n')
+
+		manifestPath := filepath.Join(outDir, scenario.name+".json")
+		result, err := translateExample(scenario, manifestJSON)
+		if err != nil {
+			return fmt.Errorf("example %q: %w", scenario.name, err)
+		}
+
+		bicepPath := filepath.Join(outDir, scenario.name+".bicep")
+
+		if opts.Check {
+			if drift := checkExampleDrift(manifestPath, manifestJSON); drift != "" {
+				driftErrs = append(driftErrs, drift)
+			}
+			if drift := checkExampleDrift(bicepPath, []byte(result.Bicep)); drift != "" {
+				driftErrs = append(driftErrs, drift)
+			}
+			continue
+		}
+
+		if err := os.WriteFile(manifestPath, manifestJSON, 0o644); err != nil {
+			return fmt.Errorf("example %q: failed to write %s: %w", scenario.name, manifestPath, err)
+		}
+		if err := os.WriteFile(bicepPath, []byte(result.Bicep), 0o644); err != nil {
+			return fmt.Errorf("example %q: failed to write %s: %w", scenario.name, bicepPath, err)
+		}
+	}
+
+	if len(driftErrs) > 0 {
+		msg := "generated examples have drifted from the committed fixtures:"
+		for _, e := range driftErrs {
+			msg += "\n  - " + e
+		}
+		return fmt.Errorf("%s", msg)
+	}
+
+	return nil
+}
+
+// translateExample writes manifestJSON to a scratch aspire-manifest.json (Translate only
+// accepts a ManifestPath on disk), runs it through the real pipeline with scenario.opts,
+// and cleans up the scratch directory before returning.
+func translateExample(scenario exampleScenario, manifestJSON []byte) (*TranslateResult, error) {
+	dir, err := os.MkdirTemp("", "aspire-example-"+scenario.name+"-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "aspire-manifest.json")
+	if err := os.WriteFile(path, manifestJSON, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write temp manifest: %w", err)
+	}
+
+	opts := scenario.opts
+	opts.ManifestPath = path
+
+	result, err := Translate(opts)
+	if err != nil {
+		return nil, fmt.Errorf("translation failed: %w", err)
+	}
+
+	return result, nil
+}
+
+// checkExampleDrift compares want against the file at path, returning a human-readable
+// description of the mismatch, or "" if they match.
+func checkExampleDrift(path string, want []byte) string {
+	got, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Sprintf("%s: %v", path, err)
+	}
+
+	if string(got) != string(want) {
+		return fmt.Sprintf("%s: does not match the freshly translated output", path)
+	}
+
+	return ""
+}
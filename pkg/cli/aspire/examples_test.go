@@ -0,0 +1,101 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateExamples_WritesOnePairPerScenario(t *testing.T) {
+	t.Parallel()
+
+	outDir := t.TempDir()
+
+	if err := GenerateExamples(outDir, ExampleOptions{}); err != nil {
+		t.Fatalf("GenerateExamples failed: %v", err)
+	}
+
+	for _, scenario := range exampleScenarios() {
+		manifestPath := filepath.Join(outDir, scenario.name+".json")
+		if _, err := os.Stat(manifestPath); err != nil {
+			t.Errorf("expected %s to exist: %v", manifestPath, err)
+		}
+
+		bicepPath := filepath.Join(outDir, scenario.name+".bicep")
+		bicep, err := os.ReadFile(bicepPath)
+		if err != nil {
+			t.Errorf("expected %s to exist: %v", bicepPath, err)
+			continue
+		}
+
+		if scenario.kind != KindUnsupported && len(bicep) == 0 {
+			t.Errorf("expected %s to contain rendered Bicep", bicepPath)
+		}
+	}
+}
+
+func TestGenerateExamples_CheckPassesWhenFixturesAreFresh(t *testing.T) {
+	t.Parallel()
+
+	outDir := t.TempDir()
+
+	if err := GenerateExamples(outDir, ExampleOptions{}); err != nil {
+		t.Fatalf("GenerateExamples failed: %v", err)
+	}
+
+	if err := GenerateExamples(outDir, ExampleOptions{Check: true}); err != nil {
+		t.Fatalf("expected no drift against freshly generated fixtures, got: %v", err)
+	}
+}
+
+func TestGenerateExamples_CheckFailsOnDrift(t *testing.T) {
+	t.Parallel()
+
+	outDir := t.TempDir()
+
+	if err := GenerateExamples(outDir, ExampleOptions{}); err != nil {
+		t.Fatalf("GenerateExamples failed: %v", err)
+	}
+
+	containerBicep := filepath.Join(outDir, "container.bicep")
+	if err := os.WriteFile(containerBicep, []byte("// tampered\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := GenerateExamples(outDir, ExampleOptions{Check: true})
+	if err == nil {
+		t.Fatal("expected drift error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "container.bicep") {
+		t.Errorf("expected drift error to name container.bicep, got: %v", err)
+	}
+}
+
+func TestGenerateExamples_CheckFailsWhenFixtureMissing(t *testing.T) {
+	t.Parallel()
+
+	outDir := t.TempDir()
+
+	err := GenerateExamples(outDir, ExampleOptions{Check: true})
+	if err == nil {
+		t.Fatal("expected drift error when no fixtures exist yet, got nil")
+	}
+}
@@ -0,0 +1,274 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"text/template"
+)
+
+// helmChartTemplate renders a chart's Chart.yaml, listing one Bitnami-style dependency per
+// distinct backing-service kind present in the manifest.
+const helmChartTemplate = `apiVersion: v2
+name: {{ .Name }}
+description: Generated from an Aspire manifest by the aspire translator.
+type: application
+version: 0.1.0
+appVersion: "1.0"
+{{- if .Dependencies }}
+dependencies:
+{{- range .Dependencies }}
+  - name: {{ .Name }}
+    version: {{ .Version }}
+    repository: {{ .Repository }}
+    alias: {{ .Alias }}
+{{- end }}
+{{- end }}
+`
+
+// helmValuesTemplate renders a minimal values.yaml: one image entry per container, so a
+// user can retag without editing the generated Deployment templates.
+const helmValuesTemplate = `image:
+{{- range .Containers }}
+  {{ .Name }}: {{ printf "%q" .Image }}
+{{- end }}
+`
+
+// helmSecretTemplate renders a Secret per backing service holding a placeholder connection
+// string. The real value depends on the hostname and credentials the chosen subchart
+// dependency actually provisions at install time, so this is a starting point the user is
+// expected to adjust (e.g. via the subchart's own values) rather than a resolved value.
+const helmSecretTemplate = `apiVersion: v1
+kind: Secret
+metadata:
+  name: {{ .Name }}
+type: Opaque
+stringData:
+  connectionString: {{ printf "%q" .ConnectionString }}
+`
+
+// helmDependency is one Chart.yaml dependency entry for a Bitnami-style backing-service
+// subchart.
+type helmDependency struct {
+	Name       string
+	Version    string
+	Repository string
+	Alias      string
+}
+
+// helmChartData is the data passed to helmChartTemplate.
+type helmChartData struct {
+	Name         string
+	Dependencies []helmDependency
+}
+
+// helmContainerImage is one entry rendered into values.yaml's image map.
+type helmContainerImage struct {
+	Name  string
+	Image string
+}
+
+// helmValuesData is the data passed to helmValuesTemplate.
+type helmValuesData struct {
+	Containers []helmContainerImage
+}
+
+// helmSecretData is the data passed to helmSecretTemplate.
+type helmSecretData struct {
+	Name             string
+	ConnectionString string
+}
+
+// helmBackingServiceTable maps a portable resource kind to the Bitnami chart dependency
+// (and placeholder connection string) emitHelmChart uses to represent it, since Helm mode
+// has a Kubernetes-native way to provision these services that plain FormatKubernetesYAML
+// does not.
+var helmBackingServiceTable = map[ResourceKind]helmDependency{
+	KindRedisCache: {Name: "redis", Version: "18.x.x", Repository: "https://charts.bitnami.com/bitnami", Alias: "redis"},
+	KindSQLDB:      {Name: "postgresql", Version: "14.x.x", Repository: "https://charts.bitnami.com/bitnami", Alias: "postgresql"},
+	KindMongoDB:    {Name: "mongodb", Version: "15.x.x", Repository: "https://charts.bitnami.com/bitnami", Alias: "mongodb"},
+	KindRabbitMQ:   {Name: "rabbitmq", Version: "14.x.x", Repository: "https://charts.bitnami.com/bitnami", Alias: "rabbitmq"},
+}
+
+// helmPlaceholderConnectionString returns the placeholder connection string
+// emitHelmChart's generated Secret carries for a backing-service resource, built from the
+// alias the corresponding Bitnami subchart installs its primary Service as.
+func helmPlaceholderConnectionString(kind ResourceKind, alias string) string {
+	switch kind {
+	case KindRedisCache:
+		return fmt.Sprintf("redis://%s-master:6379", alias)
+	case KindSQLDB:
+		return fmt.Sprintf("postgresql://postgres@%s:5432/app", alias)
+	case KindMongoDB:
+		return fmt.Sprintf("mongodb://%s:27017", alias)
+	case KindRabbitMQ:
+		return fmt.Sprintf("amqp://guest:guest@%s:5672/", alias)
+	default:
+		return ""
+	}
+}
+
+// emitHelmChart renders a Helm chart directory layout (Chart.yaml, values.yaml,
+// templates/*.yaml) from ctx, keyed by path relative to the chart root. Containers reuse
+// k8sYAMLTemplate's Deployment/Service rendering so the two Kubernetes-native backends
+// (FormatKubernetesYAML, FormatHelm) never drift apart on a container's own shape; gateway
+// routes reuse k8sRenderGatewayRoutes the same way. Backing services, which
+// FormatKubernetesYAML can only degrade to an env-var warning for, instead become a Chart.yaml
+// dependency plus a generated Secret carrying a placeholder connection string.
+func emitHelmChart(ctx *translationContext) (map[string]string, error) {
+	result := make(map[string]string)
+
+	var containerNames []string
+	for name, res := range ctx.resources {
+		if res.Kind == KindContainer {
+			containerNames = append(containerNames, name)
+		}
+	}
+	sort.Strings(containerNames)
+
+	containerTmpl, err := template.New("helm-container").Parse(k8sYAMLTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Helm container template: %w", err)
+	}
+
+	var images []helmContainerImage
+
+	for _, name := range containerNames {
+		res := ctx.resources[name]
+
+		data := k8sContainerData{
+			Name:          res.BicepIdentifier,
+			Image:         res.Container.Image,
+			Command:       res.Container.Command,
+			Args:          res.Container.Args,
+			Resources:     res.Container.Resources,
+			LivenessProbe: res.Container.LivenessProbe,
+		}
+
+		if res.Container.ImageParam != "" {
+			data.Image = res.Container.ImageParam
+		}
+
+		data.Ports = k8sBuildPorts(res.Container.Ports)
+		if res.Container.LivenessProbe != nil && len(res.Container.Ports) > 0 {
+			data.LivenessProbePort = k8sFirstPort(res.Container.Ports)
+		}
+		data.Env = k8sBuildEnv(name, res.Container.Env, ctx)
+
+		for connName := range res.Connections {
+			if ctx.kindMap[connName].IsPortableResource() {
+				ctx.addWarning(fmt.Sprintf("Resource %q: connection to %q (%s) relies on its env vars for connectivity; point its secretKeyRef at the generated %q Secret instead", name, connName, ctx.kindMap[connName], connName+"-connection"))
+			}
+		}
+
+		var buf bytes.Buffer
+		if err := containerTmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render Helm templates for %q: %w", name, err)
+		}
+
+		result[fmt.Sprintf("templates/%s.yaml", res.BicepIdentifier)] = buf.String()
+		images = append(images, helmContainerImage{Name: res.BicepIdentifier, Image: data.Image})
+	}
+
+	var dependencies []helmDependency
+	seenKinds := make(map[ResourceKind]bool)
+
+	var backingNames []string
+	for name, kind := range ctx.kindMap {
+		if kind.IsPortableResource() {
+			backingNames = append(backingNames, name)
+		}
+	}
+	sort.Strings(backingNames)
+
+	for _, name := range backingNames {
+		kind := ctx.kindMap[name]
+		dep, ok := helmBackingServiceTable[kind]
+		if !ok {
+			continue
+		}
+
+		res := ctx.resources[name]
+		bicepID := name
+		if res != nil {
+			bicepID = res.BicepIdentifier
+		}
+
+		if !seenKinds[kind] {
+			seenKinds[kind] = true
+			dependencies = append(dependencies, dep)
+		}
+
+		connString := helmPlaceholderConnectionString(kind, bicepID)
+		secretTmpl, err := template.New("helm-secret").Parse(helmSecretTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse Helm secret template: %w", err)
+		}
+
+		var buf bytes.Buffer
+		secretName := bicepID + "-connection"
+		if err := secretTmpl.Execute(&buf, helmSecretData{Name: secretName, ConnectionString: connString}); err != nil {
+			return nil, fmt.Errorf("failed to render Helm secret for %q: %w", name, err)
+		}
+
+		result[fmt.Sprintf("templates/%s-secret.yaml", bicepID)] = buf.String()
+	}
+
+	for name, res := range ctx.resources {
+		if res.Kind != KindGateway || res.Gateway == nil {
+			continue
+		}
+
+		rules := k8sBuildIngressRules(name, res.Gateway, ctx)
+		if len(rules) == 0 {
+			continue
+		}
+
+		rendered, err := k8sRenderGatewayRoutes(res.BicepIdentifier, rules, ctx.config.gatewayAPI)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render Helm gateway object for %q: %w", name, err)
+		}
+
+		result[fmt.Sprintf("templates/%s-gateway.yaml", res.BicepIdentifier)] = rendered
+	}
+
+	chartTmpl, err := template.New("helm-chart").Parse(helmChartTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Helm Chart.yaml template: %w", err)
+	}
+
+	var chartBuf bytes.Buffer
+	if err := chartTmpl.Execute(&chartBuf, helmChartData{Name: ctx.config.appName, Dependencies: dependencies}); err != nil {
+		return nil, fmt.Errorf("failed to render Helm Chart.yaml: %w", err)
+	}
+	result["Chart.yaml"] = chartBuf.String()
+
+	valuesTmpl, err := template.New("helm-values").Parse(helmValuesTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Helm values.yaml template: %w", err)
+	}
+
+	var valuesBuf bytes.Buffer
+	if err := valuesTmpl.Execute(&valuesBuf, helmValuesData{Containers: images}); err != nil {
+		return nil, fmt.Errorf("failed to render Helm values.yaml: %w", err)
+	}
+	result["values.yaml"] = valuesBuf.String()
+
+	return result, nil
+}
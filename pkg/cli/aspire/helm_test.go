@@ -0,0 +1,159 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEmitHelmChart_BasicContainer(t *testing.T) {
+	t.Parallel()
+
+	ctx := newTranslationContext(&AspireManifest{}, &translationConfig{appName: "myapp"})
+	ctx.resources["api"] = &RadiusResource{
+		BicepIdentifier: "api",
+		Kind:            KindContainer,
+		Container: &ContainerSpec{
+			Image: "myapp/api:latest",
+			Ports: map[string]PortSpec{"http": {ContainerPort: 8080, Scheme: "http"}},
+		},
+	}
+	ctx.kindMap["api"] = KindContainer
+
+	files, err := emitHelmChart(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if chart, ok := files["Chart.yaml"]; !ok || !strings.Contains(chart, "name: myapp") {
+		t.Errorf("expected Chart.yaml naming the app, got: %v", files["Chart.yaml"])
+	}
+
+	if values, ok := files["values.yaml"]; !ok || !strings.Contains(values, `myapp/api:latest`) {
+		t.Errorf("expected values.yaml to reference the container image, got: %v", values)
+	}
+
+	content, ok := files["templates/api.yaml"]
+	if !ok {
+		t.Fatalf("expected templates/api.yaml in output, got %v", files)
+	}
+
+	if !strings.Contains(content, "kind: Deployment") || !strings.Contains(content, "kind: Service") {
+		t.Errorf("expected a Deployment and Service, got:\n%s", content)
+	}
+}
+
+func TestEmitHelmChart_RedisBackingServiceBecomesDependencyAndSecret(t *testing.T) {
+	t.Parallel()
+
+	ctx := newTranslationContext(&AspireManifest{}, &translationConfig{appName: "myapp"})
+	ctx.resources["cache"] = &RadiusResource{
+		BicepIdentifier:  "cache",
+		Kind:             KindRedisCache,
+		PortableResource: &PortableResourceSpec{RecipeName: "default"},
+	}
+	ctx.kindMap["cache"] = KindRedisCache
+
+	files, err := emitHelmChart(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	chart := files["Chart.yaml"]
+	if !strings.Contains(chart, "name: redis") || !strings.Contains(chart, "charts.bitnami.com") {
+		t.Errorf("expected a Bitnami redis dependency in Chart.yaml, got:\n%s", chart)
+	}
+
+	secret, ok := files["templates/cache-secret.yaml"]
+	if !ok {
+		t.Fatalf("expected templates/cache-secret.yaml in output, got %v", files)
+	}
+
+	if !strings.Contains(secret, "kind: Secret") || !strings.Contains(secret, "connectionString:") {
+		t.Errorf("expected a connection-string Secret, got:\n%s", secret)
+	}
+}
+
+func TestEmitHelmChart_GatewayAPIRendersHTTPRoute(t *testing.T) {
+	t.Parallel()
+
+	ctx := newTranslationContext(&AspireManifest{}, &translationConfig{appName: "myapp", gatewayAPI: true})
+	ctx.resources["api"] = &RadiusResource{
+		BicepIdentifier: "api",
+		Kind:            KindContainer,
+		Container: &ContainerSpec{
+			Image: "myapp/api:latest",
+			Ports: map[string]PortSpec{"http": {ContainerPort: 8080, Scheme: "http"}},
+		},
+	}
+	ctx.kindMap["api"] = KindContainer
+	ctx.resources["gateway"] = &RadiusResource{
+		BicepIdentifier: "gateway",
+		Kind:            KindGateway,
+		Gateway: &GatewaySpec{
+			Routes: []GatewayRouteSpec{
+				{Path: "/", Hostname: "api.example.com", Destination: "http://api:8080"},
+			},
+		},
+	}
+	ctx.kindMap["gateway"] = KindGateway
+
+	files, err := emitHelmChart(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, ok := files["templates/gateway-gateway.yaml"]
+	if !ok {
+		t.Fatalf("expected templates/gateway-gateway.yaml in output, got %v", files)
+	}
+
+	if !strings.Contains(content, "kind: HTTPRoute") {
+		t.Errorf("expected an HTTPRoute when GatewayAPI is set, got:\n%s", content)
+	}
+	if !strings.Contains(content, "api.example.com") {
+		t.Errorf("expected the route's hostname in the HTTPRoute, got:\n%s", content)
+	}
+}
+
+func TestTranslate_FormatHelm(t *testing.T) {
+	t.Parallel()
+
+	manifestPath := writeManifestForLint(t, `{
+		"resources": {
+			"api": {
+				"type": "container.v0",
+				"image": "api:latest",
+				"bindings": {"http": {"scheme": "http", "protocol": "tcp", "containerPort": 8080}}
+			}
+		}
+	}`)
+
+	result, err := Translate(TranslateOptions{ManifestPath: manifestPath, EmitFormat: FormatHelm})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Bicep != "" {
+		t.Errorf("expected empty Bicep for FormatHelm, got: %q", result.Bicep)
+	}
+
+	if _, ok := result.HelmChart["Chart.yaml"]; !ok {
+		t.Fatalf("expected Chart.yaml in HelmChart output, got %v", result.HelmChart)
+	}
+}
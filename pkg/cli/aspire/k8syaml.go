@@ -0,0 +1,502 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"text/template"
+)
+
+// k8sYAMLTemplate renders a Deployment and, when the container exposes ports, a companion
+// Service, for one translated container resource.
+const k8sYAMLTemplate = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ .Name }}
+  labels:
+    app: {{ .Name }}
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: {{ .Name }}
+  template:
+    metadata:
+      labels:
+        app: {{ .Name }}
+    spec:
+      containers:
+        - name: {{ .Name }}
+          image: {{ .Image }}
+{{- if .Command }}
+          command:
+{{- range .Command }}
+            - {{ . }}
+{{- end }}
+{{- end }}
+{{- if .Args }}
+          args:
+{{- range .Args }}
+            - {{ . }}
+{{- end }}
+{{- end }}
+{{- if .Ports }}
+          ports:
+{{- range .Ports }}
+            - name: {{ .Name }}
+              containerPort: {{ .ContainerPort }}
+              protocol: {{ .Protocol }}
+{{- end }}
+{{- end }}
+{{- if .Env }}
+          env:
+{{- range .Env }}
+            - name: {{ .Name }}
+{{- if .SecretName }}
+              valueFrom:
+                secretKeyRef:
+                  name: {{ .SecretName }}
+                  key: {{ .SecretKey }}
+{{- else }}
+              value: {{ printf "%q" .Value }}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- if .Resources }}
+          resources:
+            requests:
+{{- if .Resources.CPU }}
+              cpu: {{ printf "%q" .Resources.CPU }}
+{{- end }}
+{{- if .Resources.Memory }}
+              memory: {{ printf "%q" .Resources.Memory }}
+{{- end }}
+{{- end }}
+{{- if .LivenessProbe }}
+          livenessProbe:
+{{- if eq .LivenessProbe.Kind "exec" }}
+            exec:
+              command:
+{{- range .LivenessProbe.Command }}
+                - {{ . }}
+{{- end }}
+{{- else if eq .LivenessProbe.Kind "tcp" }}
+            tcpSocket:
+              port: {{ .LivenessProbePort }}
+{{- else }}
+            httpGet:
+              path: /
+              port: {{ .LivenessProbePort }}
+{{- end }}
+{{- if .LivenessProbe.InitialDelaySeconds }}
+            initialDelaySeconds: {{ .LivenessProbe.InitialDelaySeconds }}
+{{- end }}
+{{- if .LivenessProbe.PeriodSeconds }}
+            periodSeconds: {{ .LivenessProbe.PeriodSeconds }}
+{{- end }}
+{{- if .LivenessProbe.FailureThreshold }}
+            failureThreshold: {{ .LivenessProbe.FailureThreshold }}
+{{- end }}
+{{- end }}
+{{- if .Ports }}
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: {{ .Name }}
+spec:
+  selector:
+    app: {{ .Name }}
+  ports:
+{{- range .Ports }}
+    - name: {{ .Name }}
+      port: {{ .ContainerPort }}
+      targetPort: {{ .ContainerPort }}
+      protocol: {{ .Protocol }}
+{{- end }}
+{{- end }}
+`
+
+// k8sIngressTemplate renders a single Ingress object with one rule per gateway route.
+// Routes with no Hostname match on any host (Kubernetes requires omitting the "host"
+// field in that case).
+const k8sIngressTemplate = `apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: {{ .Name }}
+spec:
+  rules:
+{{- range .Rules }}
+    - {{ if .Hostname }}host: {{ .Hostname }}
+      {{ end }}http:
+        paths:
+          - path: {{ .Path }}
+            pathType: Prefix
+            backend:
+              service:
+                name: {{ .ServiceName }}
+                port:
+                  number: {{ .ServicePort }}
+{{- end }}
+`
+
+// k8sHTTPRouteTemplate renders a single Gateway API HTTPRoute with one rule per gateway
+// route, as an alternative to k8sIngressTemplate when TranslateOptions.GatewayAPI is set.
+// It assumes a Gateway named "default" already exists in the cluster; Aspire manifests
+// have no concept of a pre-provisioned Gateway API Gateway to reference by name.
+const k8sHTTPRouteTemplate = `apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: {{ .Name }}
+spec:
+  parentRefs:
+    - name: default
+{{- if .Hostnames }}
+  hostnames:
+{{- range .Hostnames }}
+    - {{ . }}
+{{- end }}
+{{- end }}
+  rules:
+{{- range .Rules }}
+    - matches:
+        - path:
+            type: PathPrefix
+            value: {{ .Path }}
+      backendRefs:
+        - name: {{ .ServiceName }}
+          port: {{ .ServicePort }}
+{{- end }}
+`
+
+// k8sHTTPRouteData is the data passed to k8sHTTPRouteTemplate.
+type k8sHTTPRouteData struct {
+	Name      string
+	Hostnames []string
+	Rules     []k8sIngressRule
+}
+
+// k8sIngressData is the data passed to k8sIngressTemplate.
+type k8sIngressData struct {
+	Name  string
+	Rules []k8sIngressRule
+}
+
+// k8sIngressRule is one rendered Ingress rule, backed by a single gateway route.
+type k8sIngressRule struct {
+	Hostname    string
+	Path        string
+	ServiceName string
+	ServicePort int
+}
+
+// k8sBuildIngressRules converts a GatewaySpec's routes into Ingress rules. Redirect
+// routes have no plain-Ingress equivalent without an ingress-controller-specific
+// annotation, so they're skipped with a warning instead of silently dropped or
+// misrendered. TLS-terminating routes are emitted as plain rules without TLS, with a
+// warning that TLS termination is not represented.
+func k8sBuildIngressRules(gatewayName string, gateway *GatewaySpec, ctx *translationContext) []k8sIngressRule {
+	var rules []k8sIngressRule
+
+	for _, route := range gateway.Routes {
+		if route.Redirect != nil {
+			ctx.addWarning(fmt.Sprintf("Resource %q: HTTP-to-HTTPS redirect route for %q has no plain Kubernetes Ingress equivalent; omitting it from the generated Ingress", gatewayName, route.Hostname+route.Path))
+			continue
+		}
+
+		if route.TLS != nil {
+			ctx.addWarning(fmt.Sprintf("Resource %q: TLS termination for route %q is not represented in the generated Ingress; configure it on your ingress controller", gatewayName, route.Hostname+route.Path))
+		}
+
+		serviceName, servicePort, err := k8sParseDestination(route.Destination, ctx)
+		if err != nil {
+			ctx.addWarning(fmt.Sprintf("Resource %q: route %q: %s", gatewayName, route.Hostname+route.Path, err))
+			continue
+		}
+
+		path := route.Path
+		if path == "" {
+			path = "/"
+		}
+
+		rules = append(rules, k8sIngressRule{
+			Hostname:    route.Hostname,
+			Path:        path,
+			ServiceName: serviceName,
+			ServicePort: servicePort,
+		})
+	}
+
+	return rules
+}
+
+// k8sParseDestination parses a GatewayRouteSpec.Destination ("scheme://resourceName:port")
+// into the Kubernetes Service name and port backing it, translating the Aspire resource
+// name to its sanitized BicepIdentifier (the name emitKubernetesYAML gives the Service).
+func k8sParseDestination(destination string, ctx *translationContext) (serviceName string, servicePort int, err error) {
+	parsed, err := url.Parse(destination)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to parse destination %q: %w", destination, err)
+	}
+
+	port, err := strconv.Atoi(parsed.Port())
+	if err != nil {
+		return "", 0, fmt.Errorf("destination %q has no numeric port", destination)
+	}
+
+	res, ok := ctx.resources[parsed.Hostname()]
+	if !ok {
+		return "", 0, fmt.Errorf("destination %q references unknown resource %q", destination, parsed.Hostname())
+	}
+
+	return res.BicepIdentifier, port, nil
+}
+
+// k8sContainerData is the data passed to k8sYAMLTemplate for one container.
+type k8sContainerData struct {
+	Name              string
+	Image             string
+	Command           []string
+	Args              []string
+	Ports             []k8sPortEntry
+	Env               []k8sEnvEntry
+	Resources         *ContainerResourcesSpec
+	LivenessProbe     *ProbeSpec
+	LivenessProbePort int
+}
+
+// k8sPortEntry is one rendered container/service port.
+type k8sPortEntry struct {
+	Name          string
+	ContainerPort int
+	Protocol      string
+}
+
+// k8sEnvEntry is one rendered environment variable entry.
+type k8sEnvEntry struct {
+	Name       string
+	Value      string
+	SecretName string
+	SecretKey  string
+}
+
+// emitKubernetesYAML renders a Deployment (and, for containers with ports, a companion
+// Service) per Applications.Core/containers resource in ctx, keyed by filename
+// ("<identifier>.yaml"), for users deploying without Radius resource providers. Portable
+// resource connections have no Kubernetes-native equivalent, so they fall back to the env
+// vars already carrying their connection details, and a warning is recorded via
+// ctx.addWarning. Env vars backed by a Radius secret store (SecretRef) are wired to a
+// Kubernetes Secret of the same name via secretKeyRef, which the user must populate before
+// deploy when the secret backend isn't already a Kubernetes secret.
+func emitKubernetesYAML(ctx *translationContext) (map[string]string, error) {
+	tmpl, err := template.New("k8s").Parse(k8sYAMLTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Kubernetes template: %w", err)
+	}
+
+	var names []string
+	for name, res := range ctx.resources {
+		if res.Kind == KindContainer {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	result := make(map[string]string, len(names))
+
+	for _, name := range names {
+		res := ctx.resources[name]
+
+		data := k8sContainerData{
+			Name:          res.BicepIdentifier,
+			Image:         res.Container.Image,
+			Command:       res.Container.Command,
+			Args:          res.Container.Args,
+			Resources:     res.Container.Resources,
+			LivenessProbe: res.Container.LivenessProbe,
+		}
+
+		if res.Container.ImageParam != "" {
+			data.Image = res.Container.ImageParam
+		}
+
+		data.Ports = k8sBuildPorts(res.Container.Ports)
+
+		if res.Container.LivenessProbe != nil && len(res.Container.Ports) > 0 {
+			data.LivenessProbePort = k8sFirstPort(res.Container.Ports)
+		}
+
+		data.Env = k8sBuildEnv(name, res.Container.Env, ctx)
+
+		for connName := range res.Connections {
+			if ctx.kindMap[connName].IsPortableResource() {
+				ctx.addWarning(fmt.Sprintf("Resource %q: connection to %q (%s) has no Kubernetes-native mapping; relying on its env vars for connectivity", name, connName, ctx.kindMap[connName]))
+			}
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render Kubernetes manifest for %q: %w", name, err)
+		}
+
+		result[res.BicepIdentifier+".yaml"] = buf.String()
+	}
+
+	for name, res := range ctx.resources {
+		if res.Kind != KindGateway || res.Gateway == nil {
+			continue
+		}
+
+		rules := k8sBuildIngressRules(name, res.Gateway, ctx)
+		if len(rules) == 0 {
+			continue
+		}
+
+		rendered, err := k8sRenderGatewayRoutes(res.BicepIdentifier, rules, ctx.config.gatewayAPI)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render Kubernetes gateway object for %q: %w", name, err)
+		}
+
+		result[res.BicepIdentifier+".yaml"] = rendered
+	}
+
+	return result, nil
+}
+
+// k8sRenderGatewayRoutes renders a gateway's Ingress rules either as a plain
+// networking.k8s.io Ingress (the default) or, when gatewayAPI is set, as a Gateway API
+// HTTPRoute.
+func k8sRenderGatewayRoutes(name string, rules []k8sIngressRule, gatewayAPI bool) (string, error) {
+	if gatewayAPI {
+		tmpl, err := template.New("k8s-httproute").Parse(k8sHTTPRouteTemplate)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse Kubernetes HTTPRoute template: %w", err)
+		}
+
+		var buf bytes.Buffer
+		data := k8sHTTPRouteData{Name: name, Hostnames: k8sCollectHostnames(rules), Rules: rules}
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return "", fmt.Errorf("failed to render Kubernetes HTTPRoute: %w", err)
+		}
+
+		return buf.String(), nil
+	}
+
+	tmpl, err := template.New("k8s-ingress").Parse(k8sIngressTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse Kubernetes Ingress template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, k8sIngressData{Name: name, Rules: rules}); err != nil {
+		return "", fmt.Errorf("failed to render Kubernetes Ingress: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// k8sCollectHostnames returns the distinct, non-empty hostnames referenced by rules, sorted
+// for deterministic output.
+func k8sCollectHostnames(rules []k8sIngressRule) []string {
+	seen := make(map[string]bool)
+	for _, rule := range rules {
+		if rule.Hostname != "" {
+			seen[rule.Hostname] = true
+		}
+	}
+
+	hostnames := make([]string, 0, len(seen))
+	for host := range seen {
+		hostnames = append(hostnames, host)
+	}
+
+	sort.Strings(hostnames)
+
+	return hostnames
+}
+
+// k8sBuildPorts renders a container's ports map into a sorted slice of k8sPortEntry,
+// defaulting the protocol to TCP when unset.
+func k8sBuildPorts(ports map[string]PortSpec) []k8sPortEntry {
+	if len(ports) == 0 {
+		return nil
+	}
+
+	keys := sortedKeys(ports)
+
+	entries := make([]k8sPortEntry, 0, len(keys))
+	for _, name := range keys {
+		port := ports[name]
+
+		protocol := port.Protocol
+		if protocol == "" {
+			protocol = "TCP"
+		}
+
+		entries = append(entries, k8sPortEntry{Name: name, ContainerPort: port.ContainerPort, Protocol: protocol})
+	}
+
+	return entries
+}
+
+// k8sFirstPort returns the container port of the lowest-named port, for use as a liveness
+// probe's default httpGet/tcpSocket port.
+func k8sFirstPort(ports map[string]PortSpec) int {
+	keys := sortedKeys(ports)
+	return ports[keys[0]].ContainerPort
+}
+
+// k8sBuildEnv renders a container's env map into a sorted slice of k8sEnvEntry. Env vars
+// backed by a Radius secret store are wired to a Kubernetes Secret via secretKeyRef, named
+// after the synthesized secretStores resource's runtime name.
+func k8sBuildEnv(resourceName string, env map[string]EnvVarSpec, ctx *translationContext) []k8sEnvEntry {
+	var keys []string
+	for key := range env {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var entries []k8sEnvEntry
+
+	for _, key := range keys {
+		spec := env[key]
+
+		if spec.SecretRef != nil {
+			secretName := spec.SecretRef.Key
+			if store, ok := ctx.resources["secretstore"]; ok && store.SecretStore != nil {
+				secretName = store.RuntimeName
+			}
+
+			entries = append(entries, k8sEnvEntry{Name: key, SecretName: secretName, SecretKey: spec.SecretRef.Key})
+			continue
+		}
+
+		if spec.IsBicepInterpolation {
+			ctx.addWarning(fmt.Sprintf("Resource %q: env var %q resolves to a Bicep expression (%s), which Kubernetes YAML cannot evaluate; falling back to its literal value", resourceName, key, spec.Value))
+		}
+
+		entries = append(entries, k8sEnvEntry{Name: key, Value: spec.Value})
+	}
+
+	return entries
+}
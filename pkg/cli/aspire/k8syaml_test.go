@@ -0,0 +1,286 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEmitKubernetesYAML_BasicContainer(t *testing.T) {
+	t.Parallel()
+
+	ctx := newTranslationContext(&AspireManifest{}, &translationConfig{})
+	ctx.resources["api"] = &RadiusResource{
+		BicepIdentifier: "api",
+		Kind:            KindContainer,
+		Container: &ContainerSpec{
+			Image: "myapp/api:latest",
+			Ports: map[string]PortSpec{
+				"http": {ContainerPort: 8080, Scheme: "http"},
+			},
+			Env: map[string]EnvVarSpec{
+				"LOG_LEVEL": {Value: "debug"},
+			},
+		},
+	}
+	ctx.kindMap["api"] = KindContainer
+
+	files, err := emitKubernetesYAML(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, ok := files["api.yaml"]
+	if !ok {
+		t.Fatalf("expected api.yaml in output, got %v", files)
+	}
+
+	if !strings.Contains(content, "kind: Deployment") {
+		t.Errorf("expected a Deployment, got:\n%s", content)
+	}
+
+	if !strings.Contains(content, "kind: Service") {
+		t.Errorf("expected a companion Service for the ported container, got:\n%s", content)
+	}
+
+	if !strings.Contains(content, "containerPort: 8080") {
+		t.Errorf("expected containerPort 8080, got:\n%s", content)
+	}
+
+	if !strings.Contains(content, `value: "debug"`) {
+		t.Errorf("expected LOG_LEVEL env value, got:\n%s", content)
+	}
+}
+
+func TestEmitKubernetesYAML_NoPortsOmitsService(t *testing.T) {
+	t.Parallel()
+
+	ctx := newTranslationContext(&AspireManifest{}, &translationConfig{})
+	ctx.resources["worker"] = &RadiusResource{
+		BicepIdentifier: "worker",
+		Kind:            KindContainer,
+		Container: &ContainerSpec{
+			Image: "myapp/worker:latest",
+		},
+	}
+	ctx.kindMap["worker"] = KindContainer
+
+	files, err := emitKubernetesYAML(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content := files["worker.yaml"]
+
+	if strings.Contains(content, "kind: Service") {
+		t.Errorf("expected no Service when the container has no ports, got:\n%s", content)
+	}
+}
+
+func TestEmitKubernetesYAML_SecretRefBecomesSecretKeyRef(t *testing.T) {
+	t.Parallel()
+
+	ctx := newTranslationContext(&AspireManifest{}, &translationConfig{})
+	ctx.resources["secretstore"] = &RadiusResource{
+		BicepIdentifier: "secretstore",
+		RuntimeName:     "secrets",
+		Kind:            KindSecretStore,
+		SecretStore:     &SecretStoreSpec{Type: "generic"},
+	}
+	ctx.resources["api"] = &RadiusResource{
+		BicepIdentifier: "api",
+		Kind:            KindContainer,
+		Container: &ContainerSpec{
+			Image: "myapp/api:latest",
+			Env: map[string]EnvVarSpec{
+				"DB_PASSWORD": {SecretRef: &EnvSecretRefSpec{SecretStoreIdentifier: "secretstore", Key: "dbPassword"}},
+			},
+		},
+	}
+	ctx.kindMap["api"] = KindContainer
+
+	files, err := emitKubernetesYAML(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content := files["api.yaml"]
+
+	if !strings.Contains(content, "name: secrets") || !strings.Contains(content, "key: dbPassword") {
+		t.Errorf("expected DB_PASSWORD to reference the secrets Kubernetes Secret, got:\n%s", content)
+	}
+}
+
+func TestEmitKubernetesYAML_GatewayBecomesIngress(t *testing.T) {
+	t.Parallel()
+
+	ctx := newTranslationContext(&AspireManifest{}, &translationConfig{})
+	ctx.resources["api"] = &RadiusResource{
+		BicepIdentifier: "api",
+		Kind:            KindContainer,
+		Container: &ContainerSpec{
+			Image: "myapp/api:latest",
+			Ports: map[string]PortSpec{"http": {ContainerPort: 8080, Scheme: "http"}},
+		},
+	}
+	ctx.kindMap["api"] = KindContainer
+	ctx.resources["gateway"] = &RadiusResource{
+		BicepIdentifier: "gateway",
+		Kind:            KindGateway,
+		Gateway: &GatewaySpec{
+			Routes: []GatewayRouteSpec{
+				{Path: "/", Hostname: "api.example.com", Destination: "http://api:8080"},
+			},
+		},
+	}
+	ctx.kindMap["gateway"] = KindGateway
+
+	files, err := emitKubernetesYAML(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, ok := files["gateway.yaml"]
+	if !ok {
+		t.Fatalf("expected gateway.yaml in output, got %v", files)
+	}
+
+	if !strings.Contains(content, "kind: Ingress") {
+		t.Errorf("expected an Ingress object, got:\n%s", content)
+	}
+	if !strings.Contains(content, "host: api.example.com") {
+		t.Errorf("expected the route's hostname as the Ingress rule host, got:\n%s", content)
+	}
+	if !strings.Contains(content, "name: api") || !strings.Contains(content, "number: 8080") {
+		t.Errorf("expected the Ingress backend to reference the api Service on port 8080, got:\n%s", content)
+	}
+}
+
+func TestEmitKubernetesYAML_GatewayAPIRendersHTTPRoute(t *testing.T) {
+	t.Parallel()
+
+	ctx := newTranslationContext(&AspireManifest{}, &translationConfig{gatewayAPI: true})
+	ctx.resources["api"] = &RadiusResource{
+		BicepIdentifier: "api",
+		Kind:            KindContainer,
+		Container: &ContainerSpec{
+			Image: "myapp/api:latest",
+			Ports: map[string]PortSpec{"http": {ContainerPort: 8080, Scheme: "http"}},
+		},
+	}
+	ctx.kindMap["api"] = KindContainer
+	ctx.resources["gateway"] = &RadiusResource{
+		BicepIdentifier: "gateway",
+		Kind:            KindGateway,
+		Gateway: &GatewaySpec{
+			Routes: []GatewayRouteSpec{
+				{Path: "/", Hostname: "api.example.com", Destination: "http://api:8080"},
+			},
+		},
+	}
+	ctx.kindMap["gateway"] = KindGateway
+
+	files, err := emitKubernetesYAML(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, ok := files["gateway.yaml"]
+	if !ok {
+		t.Fatalf("expected gateway.yaml in output, got %v", files)
+	}
+
+	if !strings.Contains(content, "kind: HTTPRoute") {
+		t.Errorf("expected an HTTPRoute when GatewayAPI is set, got:\n%s", content)
+	}
+	if !strings.Contains(content, "api.example.com") {
+		t.Errorf("expected the route's hostname in the HTTPRoute, got:\n%s", content)
+	}
+	if !strings.Contains(content, "name: api") || !strings.Contains(content, "port: 8080") {
+		t.Errorf("expected the HTTPRoute backendRef to reference the api Service on port 8080, got:\n%s", content)
+	}
+}
+
+func TestEmitKubernetesYAML_GatewayRedirectRouteSkippedWithWarning(t *testing.T) {
+	t.Parallel()
+
+	ctx := newTranslationContext(&AspireManifest{}, &translationConfig{})
+	ctx.resources["gateway"] = &RadiusResource{
+		BicepIdentifier: "gateway",
+		Kind:            KindGateway,
+		Gateway: &GatewaySpec{
+			Routes: []GatewayRouteSpec{
+				{Path: "/", Hostname: "api.example.com", Redirect: &RedirectSpec{Destination: "https://api.example.com/", StatusCode: 301}},
+			},
+		},
+	}
+	ctx.kindMap["gateway"] = KindGateway
+
+	files, err := emitKubernetesYAML(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := files["gateway.yaml"]; ok {
+		t.Errorf("expected no Ingress when every route is a redirect, got %v", files)
+	}
+
+	found := false
+	for _, w := range ctx.warnings {
+		if strings.Contains(w, "redirect") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a warning about the skipped redirect route, got: %v", ctx.warnings)
+	}
+}
+
+func TestEmitKubernetesYAML_PortableResourceDegradation(t *testing.T) {
+	t.Parallel()
+
+	ctx := newTranslationContext(&AspireManifest{}, &translationConfig{})
+	ctx.resources["api"] = &RadiusResource{
+		BicepIdentifier: "api",
+		Kind:            KindContainer,
+		Container: &ContainerSpec{
+			Image: "myapp/api:latest",
+			Env:   map[string]EnvVarSpec{},
+		},
+		Connections: map[string]ConnectionSpec{
+			"cache": {Source: "redis connection string"},
+		},
+	}
+	ctx.kindMap["api"] = KindContainer
+	ctx.kindMap["cache"] = KindRedisCache
+
+	if _, err := emitKubernetesYAML(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	found := false
+	for _, w := range ctx.warnings {
+		if strings.Contains(w, "cache") && strings.Contains(w, "no Kubernetes-native mapping") {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Errorf("expected a warning about the redis connection having no Kubernetes-native mapping, got: %v", ctx.warnings)
+	}
+}
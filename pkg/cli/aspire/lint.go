@@ -0,0 +1,251 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Severity classifies how serious a Diagnostic is.
+type Severity string
+
+const (
+	// SeverityError marks a diagnostic that would also fail Translate.
+	SeverityError Severity = "error"
+
+	// SeverityWarning marks a diagnostic that Translate would merely record in
+	// TranslateResult.Warnings rather than fail on.
+	SeverityWarning Severity = "warning"
+
+	// SeverityInfo marks an advisory diagnostic with no equivalent in Translate.
+	SeverityInfo Severity = "info"
+)
+
+// Diagnostic is a single issue found by Lint. Its JSON tags are chosen to render well as
+// a GitHub Actions/SARIF-style annotation: Severity, Path, and Message are the fields such
+// consumers expect to surface inline on a PR.
+type Diagnostic struct {
+	// Severity classifies how serious the diagnostic is.
+	Severity Severity `json:"severity"`
+
+	// ResourceName is the Aspire resource the diagnostic concerns, empty for
+	// manifest-wide issues.
+	ResourceName string `json:"resourceName,omitempty"`
+
+	// Path is a JSON-pointer-like location within the manifest (e.g.
+	// "resources.api.env.DB_URL"), empty when the diagnostic isn't tied to one field.
+	Path string `json:"path,omitempty"`
+
+	// Code is a stable identifier (e.g. "ASPIRE001") a CI consumer can use to suppress or
+	// triage this class of diagnostic.
+	Code string `json:"code"`
+
+	// Message describes the issue.
+	Message string `json:"message"`
+
+	// Suggestion is an optional suggested fix.
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// Diagnostic codes. Stable once published: a CI consumer may key suppression rules off
+// these, so a code must never be reassigned to a different kind of issue.
+const (
+	codeSchemaViolation     = "ASPIRE001"
+	codeUnknownReference    = "ASPIRE002"
+	codeCircularReference   = "ASPIRE003"
+	codeUnsupportedType     = "ASPIRE004"
+	codeResourceMapping     = "ASPIRE005"
+	codeGatewayCollision    = "ASPIRE006"
+	codeIdentifierCollision = "ASPIRE007"
+	codeWarning             = "ASPIRE999"
+)
+
+// Lint walks the same parsing, reference-resolution, and resource-mapping pipeline as
+// Translate, but instead of emitting Bicep (or failing fast on the first problem) it
+// accumulates every diagnostic it finds and returns them all, so it can be run as a static
+// checker in CI without Bicep ever being generated.
+func Lint(opts TranslateOptions) ([]Diagnostic, error) {
+	var manifest *AspireManifest
+	var origin map[string]string
+
+	if opts.ComposePath != "" {
+		m, err := ComposeToManifest(opts.ComposePath)
+		if err != nil {
+			return nil, err
+		}
+
+		manifest = m
+		origin = make(map[string]string, len(manifest.Resources))
+		for name := range manifest.Resources {
+			origin[name] = opts.ComposePath
+		}
+	} else {
+		paths, err := resolveManifestPaths(opts)
+		if err != nil {
+			return nil, err
+		}
+
+		m, o, err := loadManifests(paths)
+		if err != nil {
+			return nil, err
+		}
+
+		manifest = m
+		origin = o
+	}
+
+	config := newTranslationConfig(opts)
+	ctx := newTranslationContext(manifest, config)
+	ctx.origin = origin
+	ctx.env = opts.Env
+
+	var diagnostics []Diagnostic
+
+	for _, warning := range manifest.Warnings {
+		diagnostics = append(diagnostics, Diagnostic{Severity: SeverityWarning, Code: codeWarning, Message: warning})
+	}
+
+	validateManifestSchema(ctx)
+	for _, err := range ctx.errors {
+		diagnostics = append(diagnostics, Diagnostic{
+			Severity: SeverityError,
+			Code:     codeSchemaViolation,
+			Message:  err.Error(),
+		})
+	}
+	ctx.errors = nil
+
+	if err := detectCircularReferences(ctx); err != nil {
+		var cycleErr *circularReferenceError
+		if errors.As(err, &cycleErr) {
+			for _, cycle := range cycleErr.cycles {
+				diagnostics = append(diagnostics, Diagnostic{
+					Severity:   SeverityError,
+					Code:       codeCircularReference,
+					Message:    fmt.Sprintf("circular reference among resources [%s]", strings.Join(cycle.resources, ", ")),
+					Suggestion: "break the cycle by removing or rewriting one of the expressions involved",
+				})
+			}
+		} else {
+			diagnostics = append(diagnostics, Diagnostic{Severity: SeverityError, Code: codeCircularReference, Message: err.Error()})
+		}
+	}
+
+	diagnostics = append(diagnostics, lintExpressionReferences(ctx)...)
+
+	classifyResources(ctx)
+	for name, kind := range ctx.kindMap {
+		if kind == KindUnsupported {
+			diagnostics = append(diagnostics, Diagnostic{
+				Severity:     SeverityWarning,
+				ResourceName: name,
+				Path:         fmt.Sprintf("resources.%s.type", name),
+				Code:         codeUnsupportedType,
+				Message:      fmt.Sprintf("unrecognized resource type %q", manifest.Resources[name].Type),
+			})
+		}
+	}
+	ctx.warnings = nil
+
+	if isEmptyManifest(ctx) {
+		return diagnostics, nil
+	}
+
+	if err := sanitizeIdentifiers(ctx); err != nil {
+		diagnostics = append(diagnostics, Diagnostic{Severity: SeverityError, Code: codeIdentifierCollision, Message: err.Error()})
+		return diagnostics, nil
+	}
+
+	diagnostics = append(diagnostics, lintMapResources(ctx)...)
+
+	if _, err := synthesizeGateway(ctx); err != nil {
+		diagnostics = append(diagnostics, Diagnostic{Severity: SeverityError, Code: codeGatewayCollision, Message: err.Error()})
+	}
+
+	for _, warning := range ctx.warnings {
+		diagnostics = append(diagnostics, Diagnostic{Severity: SeverityWarning, Code: codeWarning, Message: warning})
+	}
+
+	return diagnostics, nil
+}
+
+// lintExpressionReferences is the lint-mode counterpart to validateExpressionReferences: it
+// reports every unknown reference found across every resource, instead of returning on the
+// first one.
+func lintExpressionReferences(ctx *translationContext) []Diagnostic {
+	var diagnostics []Diagnostic
+
+	var names []string
+	for name := range ctx.manifest.Resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		resource := ctx.manifest.Resources[name]
+		allValues := collectAllExpressionValues(resource)
+
+		for _, value := range allValues {
+			cv := parseExpressions(value)
+			for _, part := range cv.parts {
+				for _, targetName := range collectValidatedRefs(part.expression) {
+					if _, exists := ctx.manifest.Resources[targetName]; !exists {
+						diagnostics = append(diagnostics, Diagnostic{
+							Severity:     SeverityError,
+							ResourceName: name,
+							Path:         fmt.Sprintf("resources.%s.env", name),
+							Code:         codeUnknownReference,
+							Message:      fmt.Sprintf("expression %q references unknown resource %q", part.expression.RawText, targetName),
+							Suggestion:   fmt.Sprintf("define resource %q or fix the reference", targetName),
+						})
+					}
+				}
+			}
+		}
+	}
+
+	return diagnostics
+}
+
+// lintMapResources is the lint-mode counterpart to mapResources: it keeps mapping the
+// remaining resources after one fails, turning each failure into a Diagnostic instead of
+// aborting the whole run.
+func lintMapResources(ctx *translationContext) []Diagnostic {
+	var diagnostics []Diagnostic
+
+	var names []string
+	for name := range ctx.kindMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := mapOneResource(name, ctx.kindMap[name], ctx); err != nil {
+			diagnostics = append(diagnostics, Diagnostic{
+				Severity:     SeverityError,
+				ResourceName: name,
+				Code:         codeResourceMapping,
+				Message:      err.Error(),
+			})
+		}
+	}
+
+	return diagnostics
+}
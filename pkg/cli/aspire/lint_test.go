@@ -0,0 +1,212 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeManifestForLint(t *testing.T, content string) string {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	manifestPath := filepath.Join(tmpDir, "aspire-manifest.json")
+	if err := os.WriteFile(manifestPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return manifestPath
+}
+
+func TestLint_CollectsAllBrokenReferences(t *testing.T) {
+	t.Parallel()
+
+	manifestPath := writeManifestForLint(t, `{
+		"resources": {
+			"api": {
+				"type": "container.v0",
+				"image": "api:latest",
+				"env": {
+					"DB_URL": "{nonexistent.bindings.http.url}",
+					"CACHE_URL": "{alsomissing.bindings.http.url}"
+				}
+			}
+		}
+	}`)
+
+	diagnostics, err := Lint(TranslateOptions{ManifestPath: manifestPath})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var unknownRefs int
+	for _, d := range diagnostics {
+		if d.Code == codeUnknownReference {
+			unknownRefs++
+		}
+	}
+
+	if unknownRefs != 2 {
+		t.Fatalf("expected 2 unknown-reference diagnostics, got %d: %+v", unknownRefs, diagnostics)
+	}
+}
+
+func TestLint_CircularReferenceReportsFullCycle(t *testing.T) {
+	t.Parallel()
+
+	manifestPath := writeManifestForLint(t, `{
+		"resources": {
+			"a": {"type": "value.v0", "connectionString": "{b.connectionString}"},
+			"b": {"type": "value.v0", "connectionString": "{a.connectionString}"}
+		}
+	}`)
+
+	diagnostics, err := Lint(TranslateOptions{ManifestPath: manifestPath})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	found := false
+	for _, d := range diagnostics {
+		if d.Code == codeCircularReference && strings.Contains(d.Message, "a") && strings.Contains(d.Message, "b") {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Fatalf("expected a circular-reference diagnostic naming both resources, got: %+v", diagnostics)
+	}
+}
+
+func TestLint_MissingImageMappingDoesNotAbortOtherDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	manifestPath := writeManifestForLint(t, `{
+		"resources": {
+			"web": {"type": "project.v1"},
+			"api": {
+				"type": "container.v0",
+				"image": "api:latest",
+				"env": {"DB_URL": "{missing.connectionString}"}
+			}
+		}
+	}`)
+
+	diagnostics, err := Lint(TranslateOptions{ManifestPath: manifestPath})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var mappingDiag, refDiag bool
+	for _, d := range diagnostics {
+		if d.Code == codeResourceMapping && d.ResourceName == "web" {
+			mappingDiag = true
+		}
+		if d.Code == codeUnknownReference && d.ResourceName == "api" {
+			refDiag = true
+		}
+	}
+
+	if !mappingDiag {
+		t.Errorf("expected a resource-mapping diagnostic for the unmapped project resource, got: %+v", diagnostics)
+	}
+	if !refDiag {
+		t.Errorf("expected the unrelated broken reference to still be reported, got: %+v", diagnostics)
+	}
+}
+
+func TestLint_UnsupportedResourceType(t *testing.T) {
+	t.Parallel()
+
+	manifestPath := writeManifestForLint(t, `{
+		"resources": {
+			"api": {"type": "some.unrecognized.v0"}
+		}
+	}`)
+
+	diagnostics, err := Lint(TranslateOptions{ManifestPath: manifestPath})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	found := false
+	for _, d := range diagnostics {
+		if d.Code == codeUnsupportedType && d.ResourceName == "api" && d.Severity == SeverityWarning {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Fatalf("expected an unsupported-type warning diagnostic, got: %+v", diagnostics)
+	}
+}
+
+func TestLint_CleanManifestProducesNoErrorDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	manifestPath := writeManifestForLint(t, `{
+		"resources": {
+			"api": {
+				"type": "container.v0",
+				"image": "api:latest",
+				"bindings": {"http": {"scheme": "http", "protocol": "tcp", "containerPort": 8080}}
+			}
+		}
+	}`)
+
+	diagnostics, err := Lint(TranslateOptions{ManifestPath: manifestPath})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, d := range diagnostics {
+		if d.Severity == SeverityError {
+			t.Errorf("expected no error diagnostics for a clean manifest, got: %+v", d)
+		}
+	}
+}
+
+func TestLint_DiagnosticMarshalsForGitHubActionsStyleConsumption(t *testing.T) {
+	t.Parallel()
+
+	d := Diagnostic{
+		Severity:     SeverityError,
+		ResourceName: "api",
+		Path:         "resources.api.env.DB_URL",
+		Code:         codeUnknownReference,
+		Message:      `expression references unknown resource "missing"`,
+		Suggestion:   `define resource "missing" or fix the reference`,
+	}
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("unexpected error marshaling diagnostic: %v", err)
+	}
+
+	var roundTripped Diagnostic
+	if err := json.Unmarshal(data, &roundTripped); err != nil {
+		t.Fatalf("unexpected error unmarshaling diagnostic: %v", err)
+	}
+
+	if roundTripped != d {
+		t.Errorf("round-tripped diagnostic %+v does not match original %+v", roundTripped, d)
+	}
+}
@@ -17,12 +17,12 @@ limitations under the License.
 package aspire
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 )
 
-// parseManifest reads and validates the Aspire manifest JSON file at the given path.
+// parseManifest reads and validates the Aspire manifest JSON file at the given path,
+// dispatching to the manifestDecoder registered for its "$schema" value.
 func parseManifest(path string) (*AspireManifest, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -33,29 +33,67 @@ func parseManifest(path string) (*AspireManifest, error) {
 		return nil, fmt.Errorf("failed to read manifest file: %w", err)
 	}
 
-	var manifest AspireManifest
-	if err := json.Unmarshal(data, &manifest); err != nil {
-		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	schema, err := peekSchema(data)
+	if err != nil {
+		return nil, err
 	}
 
+	decoder := decoderForSchema(schema)
+
+	manifest, err := decoder.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest.RawJSON = data
+
 	if manifest.Resources == nil {
 		return nil, fmt.Errorf("failed to parse manifest: missing required field \"resources\"")
 	}
 
-	// Validate that all resources have a type field.
+	// Validate that all resources have a type field, and warn (rather than silently
+	// falling through to a KindUnsupported classification later) about any type this
+	// decoder doesn't recognize.
 	for name, resource := range manifest.Resources {
 		if resource.Type == "" {
 			return nil, fmt.Errorf("failed to parse manifest: resource %q missing required field \"type\"", name)
 		}
+
+		if !resourceTypeSupported(decoder, resource.Type) {
+			manifest.Warnings = append(manifest.Warnings, fmt.Sprintf("resource %q has type %q, which is not recognized for manifest schema %q", name, resource.Type, schema))
+		}
 	}
 
-	return &manifest, nil
+	return manifest, nil
 }
 
 // AspireManifest represents the top-level Aspire manifest structure.
 type AspireManifest struct {
 	Schema    string                      `json:"$schema"`
 	Resources map[string]ManifestResource `json:"resources"`
+
+	// Warnings accumulates non-fatal decode-time issues (e.g. a resource type not
+	// recognized by the decoder chosen for Schema). Never populated from JSON; merged
+	// into the translation context's warnings once loaded.
+	Warnings []string `json:"-"`
+
+	// RawJSON holds the manifest's original bytes, set by parseManifest so
+	// validateManifestSchema can validate them against Schema's JSON Schema document.
+	// Never populated from JSON; left empty for manifests built without a single source
+	// file (ComposeToManifest, mergeManifests), which skip schema validation.
+	RawJSON []byte `json:"-"`
+
+	// Gateway holds manifest-wide gateway settings that apply to every synthesized
+	// gateway route, unless a binding's own ManifestBinding.SourceRanges overrides them.
+	Gateway *ManifestGatewayConfig `json:"gateway,omitempty"`
+}
+
+// ManifestGatewayConfig holds manifest-wide settings for the gateway synthesized from
+// external bindings.
+type ManifestGatewayConfig struct {
+	// AllowedSourceRanges is a default CIDR allowlist applied to every external binding
+	// that doesn't declare its own ManifestBinding.SourceRanges.
+	AllowedSourceRanges []string `json:"allowedSourceRanges,omitempty"`
 }
 
 // ManifestResource represents a single resource in the Aspire manifest.
@@ -72,6 +110,48 @@ type ManifestResource struct {
 	BindMounts       []ManifestBindMount           `json:"bindMounts,omitempty"`
 	Value            string                        `json:"value,omitempty"`
 	Inputs           map[string]ManifestParamInput `json:"inputs,omitempty"`
+	Options          []string                      `json:"options,omitempty"`
+	HealthCheck      *ManifestHealthCheck          `json:"healthCheck,omitempty"`
+
+	// BuildOnly marks this resource as a build-output companion rather than a
+	// standalone resource: it supplies Image for the project.v0/v1 resource named by
+	// Parent instead of being translated itself (see KindBuildOutput).
+	BuildOnly bool `json:"buildOnly,omitempty"`
+
+	// Parent names the project.v0/v1 resource a BuildOnly resource supplies Image for.
+	Parent string `json:"parent,omitempty"`
+
+	// Deployment carries project.v1's optional deployment metadata (e.g. publish
+	// profile, target environment). It is not yet consumed by mapContainer — project.v0
+	// and project.v1 resources are mapped identically — but is captured here so a
+	// round-trip through parseManifest doesn't lose it.
+	Deployment map[string]any `json:"deployment,omitempty"`
+}
+
+// ManifestHealthCheck configures the liveness/readiness probe synthesized for a
+// container.v0/v1 resource's Binding, mapped to ContainerSpec.LivenessProbe/ReadinessProbe
+// by mapHealthCheckProbe.
+type ManifestHealthCheck struct {
+	// Path is the HTTP path to probe. Only meaningful when Binding resolves to an
+	// "http"/"https"-scheme binding.
+	Path string `json:"path,omitempty"`
+
+	// Binding names the ManifestResource.Bindings entry to probe. Defaults to "http"
+	// when empty.
+	Binding string `json:"binding,omitempty"`
+
+	// IntervalSeconds is the interval between probes. Defaults to 10 when zero.
+	IntervalSeconds int `json:"intervalSeconds,omitempty"`
+
+	// InitialDelaySeconds is the delay before the first probe. Defaults to 0 when zero.
+	InitialDelaySeconds int `json:"initialDelaySeconds,omitempty"`
+
+	// TimeoutSeconds is the per-probe timeout. Defaults to 1 when zero.
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+
+	// FailureThreshold is the number of consecutive failures before giving up. Defaults
+	// to 3 when zero.
+	FailureThreshold int `json:"failureThreshold,omitempty"`
 }
 
 // ManifestBinding represents a network binding on an Aspire resource.
@@ -82,6 +162,28 @@ type ManifestBinding struct {
 	Port       int    `json:"port,omitempty"`
 	TargetPort int    `json:"targetPort,omitempty"`
 	External   bool   `json:"external,omitempty"`
+
+	// Host is the gateway route hostname for this binding, used when synthesizing a
+	// gateway. Defaults to the resource name when empty.
+	Host string `json:"host,omitempty"`
+
+	// Path is the gateway route path prefix for this binding, used when synthesizing a
+	// gateway. Defaults to "/" when empty.
+	Path string `json:"path,omitempty"`
+
+	// TLSCertificateSecret references the secret holding the TLS certificate for this
+	// binding's gateway route. Only meaningful on "https" bindings.
+	TLSCertificateSecret string `json:"tlsCertificateSecret,omitempty"`
+
+	// RedirectPermanent selects a 301 (true) over a 302 (false) status code for the
+	// HTTP-to-HTTPS redirect route synthesized when a resource exposes both an "http"
+	// and an "https" external binding.
+	RedirectPermanent bool `json:"redirectPermanent,omitempty"`
+
+	// SourceRanges is a CIDR allowlist restricting which client IPs may reach this
+	// binding's gateway route. Overrides AspireManifest.Gateway.AllowedSourceRanges when
+	// set; an empty list falls back to it. When both are empty the route is unrestricted.
+	SourceRanges []string `json:"sourceRanges,omitempty"`
 }
 
 // ManifestVolumeMount represents a named volume mount.
@@ -0,0 +1,111 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// manifestDecoder normalizes one version of the Aspire manifest JSON schema into the
+// package's internal AspireManifest representation. New Aspire schema versions that
+// rename or restructure fields get their own decoder rather than special-casing the
+// difference inline in parseManifest or classify.
+type manifestDecoder interface {
+	// Decode parses raw manifest JSON into the internal AspireManifest shape.
+	Decode(data []byte) (*AspireManifest, error)
+
+	// SupportedTypes returns the resource "type" prefixes this decoder understands
+	// (e.g. "container.v" for "container.v0"/"container.v1", "value.v0" for an exact
+	// match), mapped to the ResourceKind classify() treats them as. Resource types
+	// present in a manifest but matched by none of these are reported as a decode-time
+	// warning rather than surfacing only as a late "unsupported" classification.
+	SupportedTypes() map[string]ResourceKind
+}
+
+// manifestDecoders maps a manifest's "$schema" value to the decoder that understands it.
+// Manifests with an unrecognized (or absent) $schema fall back to defaultManifestDecoder.
+var manifestDecoders = map[string]manifestDecoder{
+	"https://json.schemastore.org/aspire-8.0.json": defaultManifestDecoder{},
+	"https://json.schemastore.org/aspire-9.0.json": defaultManifestDecoder{},
+}
+
+// decoderForSchema returns the manifestDecoder registered for schema, falling back to
+// defaultManifestDecoder for an empty or unrecognized $schema value.
+func decoderForSchema(schema string) manifestDecoder {
+	if decoder, ok := manifestDecoders[schema]; ok {
+		return decoder
+	}
+
+	return defaultManifestDecoder{}
+}
+
+// peekSchema extracts the "$schema" field from raw manifest JSON without decoding the
+// rest of the document, so parseManifest can pick a manifestDecoder before committing to
+// a full unmarshal.
+func peekSchema(data []byte) (string, error) {
+	var head struct {
+		Schema string `json:"$schema"`
+	}
+
+	if err := json.Unmarshal(data, &head); err != nil {
+		return "", fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	return head.Schema, nil
+}
+
+// resourceTypeSupported reports whether resourceType is recognized by decoder, matching
+// entries in SupportedTypes() either exactly (e.g. "value.v0") or as a prefix (e.g.
+// "container.v" matching "container.v0" and "container.v1").
+func resourceTypeSupported(decoder manifestDecoder, resourceType string) bool {
+	for known := range decoder.SupportedTypes() {
+		if resourceType == known || strings.HasPrefix(resourceType, known) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// defaultManifestDecoder decodes the Aspire manifest shape currently documented by
+// AspireManifest/ManifestResource's JSON tags, where every field already lines up
+// one-to-one with the wire format. It backstops manifests with no $schema field (or an
+// unrecognized one), which is the common case for hand-written manifests and for
+// manifests produced by older Aspire CLI versions predating manifestDecoders.
+type defaultManifestDecoder struct{}
+
+// Decode implements manifestDecoder.
+func (defaultManifestDecoder) Decode(data []byte) (*AspireManifest, error) {
+	var manifest AspireManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	return &manifest, nil
+}
+
+// SupportedTypes implements manifestDecoder.
+func (defaultManifestDecoder) SupportedTypes() map[string]ResourceKind {
+	return map[string]ResourceKind{
+		"container.v":  KindContainer,
+		"project.v":    KindContainer,
+		"value.v0":     KindValueResource,
+		"parameter.v0": KindParameter,
+	}
+}
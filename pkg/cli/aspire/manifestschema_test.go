@@ -0,0 +1,133 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestParseManifest_SchemaCompatibilityMatrix feeds a manifest through every registered
+// schema, plus an unrecognized one, and asserts each normalizes to the same internal
+// AspireManifest shape via defaultManifestDecoder.
+func TestParseManifest_SchemaCompatibilityMatrix(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		schema string
+	}{
+		{name: "aspire 8.0", schema: "https://json.schemastore.org/aspire-8.0.json"},
+		{name: "aspire 9.0", schema: "https://json.schemastore.org/aspire-9.0.json"},
+		{name: "unrecognized schema falls back to default decoder", schema: "https://json.schemastore.org/aspire-99.0.json"},
+		{name: "no schema falls back to default decoder", schema: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			content := `{"$schema": "` + tt.schema + `", "resources": {"api": {"type": "container.v0", "image": "myapp/api:latest"}}}`
+
+			tmpDir := t.TempDir()
+			path := filepath.Join(tmpDir, "aspire-manifest.json")
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			manifest, err := parseManifest(path)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			api, ok := manifest.Resources["api"]
+			if !ok {
+				t.Fatal("expected 'api' resource")
+			}
+
+			if api.Type != "container.v0" {
+				t.Errorf("expected type 'container.v0', got %q", api.Type)
+			}
+
+			if api.Image != "myapp/api:latest" {
+				t.Errorf("expected image 'myapp/api:latest', got %q", api.Image)
+			}
+
+			if len(manifest.Warnings) != 0 {
+				t.Errorf("expected no warnings for a recognized resource type, got %v", manifest.Warnings)
+			}
+		})
+	}
+}
+
+func TestParseManifest_UnrecognizedResourceTypeWarns(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "aspire-manifest.json")
+	content := `{"resources": {"future": {"type": "quantum.v0"}}}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest, err := parseManifest(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(manifest.Warnings) != 1 {
+		t.Fatalf("expected exactly one warning for an unrecognized resource type, got %v", manifest.Warnings)
+	}
+}
+
+func TestDecoderForSchema(t *testing.T) {
+	t.Parallel()
+
+	if _, ok := decoderForSchema("https://json.schemastore.org/aspire-8.0.json").(defaultManifestDecoder); !ok {
+		t.Error("expected the aspire-8.0 schema to resolve to defaultManifestDecoder")
+	}
+
+	if _, ok := decoderForSchema("bogus").(defaultManifestDecoder); !ok {
+		t.Error("expected an unrecognized schema to fall back to defaultManifestDecoder")
+	}
+}
+
+func TestResourceTypeSupported(t *testing.T) {
+	t.Parallel()
+
+	decoder := defaultManifestDecoder{}
+
+	tests := []struct {
+		resourceType string
+		expected     bool
+	}{
+		{"container.v0", true},
+		{"container.v1", true},
+		{"project.v0", true},
+		{"project.v1", true},
+		{"value.v0", true},
+		{"parameter.v0", true},
+		{"quantum.v0", false},
+	}
+
+	for _, tt := range tests {
+		if got := resourceTypeSupported(decoder, tt.resourceType); got != tt.expected {
+			t.Errorf("resourceTypeSupported(%q) = %v, want %v", tt.resourceType, got, tt.expected)
+		}
+	}
+}
@@ -18,12 +18,26 @@ package aspire
 
 import (
 	"fmt"
+	"net"
+	"sort"
 	"strings"
 )
 
 // classify determines the ResourceKind for a manifest resource based on its type,
-// image, and any user overrides.
-func classify(name string, resource ManifestResource, overrides map[string]ResourceKind) ResourceKind {
+// image, and any user overrides. addWarning records non-fatal outcomes (e.g. a failed
+// registry resolver lookup); it may be nil.
+func classify(name string, resource ManifestResource, cfg *translationConfig, addWarning func(string)) ResourceKind {
+	if addWarning == nil {
+		addWarning = func(string) {}
+	}
+
+	var overrides map[string]ResourceKind
+	var resolver RegistryResolver
+	if cfg != nil {
+		overrides = cfg.resourceOverrides
+		resolver = cfg.registryResolver
+	}
+
 	// Check for user overrides first.
 	if overrides != nil {
 		if kind, ok := overrides[name]; ok {
@@ -31,53 +45,76 @@ func classify(name string, resource ManifestResource, overrides map[string]Resou
 		}
 	}
 
-	switch {
-	case strings.HasPrefix(resource.Type, "container.v"):
-		// Check for backing service detection.
-		if resource.Image != "" {
-			if kind := detectBackingService(resource.Image); kind != KindUnsupported {
-				return kind
-			}
-		}
-
-		return KindContainer
-
-	case strings.HasPrefix(resource.Type, "project.v"):
-		return KindContainer
-
-	case resource.Type == "value.v0":
-		return KindValueResource
+	// A BuildOnly resource is a build-output companion, not a standalone resource: it's
+	// inlined into its Parent project.v0/v1 resource by mapContainer instead.
+	if resource.BuildOnly {
+		return KindBuildOutput
+	}
 
-	case resource.Type == "parameter.v0":
-		return KindParameter
+	// Backing-service detection is orthogonal to type-based classification: a
+	// container.v0/v1 resource whose image matches (or resolves to) a known backing
+	// service is reclassified before ever consulting the resource-type registry below.
+	if strings.HasPrefix(resource.Type, "container.v") && resource.Image != "" {
+		if kind := classifyImage(name, resource.Image, resolver, addWarning); kind != KindUnsupported {
+			return kind
+		}
+	}
 
-	default:
-		return KindUnsupported
+	if handler, ok := handlerForType(resource.Type); ok {
+		return handler.Kind(resource)
 	}
+
+	return KindUnsupported
 }
 
 // mapContainer converts a ManifestResource into a RadiusResource with ContainerSpec.
-func mapContainer(name string, resource ManifestResource, bicepID string, imageMappings map[string]string) (*RadiusResource, error) {
+// For project.v0/v1 resources, builtImage is non-empty when the image was produced by an
+// automatic build (any BuildMode other than BuildModePlaceholderParam) rather than an
+// explicit ImageMappings entry or a BuildOnly companion resource. warnings reports any resource.Options entries that were not
+// recognized docker-create-style runtime flags. ctx.imageDigests/imagePorts cache the
+// registry resolver lookups below, keyed by image reference, so resources sharing an image
+// only hit the registry once per translation.
+func mapContainer(name string, resource ManifestResource, bicepID string, ctx *translationContext) (result *RadiusResource, builtImage string, warnings []string, err error) {
+	cfg := ctx.config
 	image := resource.Image
+	var imageParam string
+	var needsBuildWarning string
 
-	// For project resources, look up image in mappings.
+	// For project resources, look up image in mappings, then a BuildOnly companion
+	// resource, falling back to an automatic build.
 	if strings.HasPrefix(resource.Type, "project.v") {
-		mappedImage, ok := imageMappings[name]
+		mappedImage, ok := cfg.imageMappings[name]
+		if !ok {
+			mappedImage, ok = buildCompanionImage(ctx, name)
+		}
+
 		if !ok {
-			return nil, &missingImageMappingError{resourceName: name}
+			built, buildErr := buildProjectImage(name, resource, cfg)
+			if buildErr != nil {
+				return nil, "", nil, buildErr
+			}
+
+			if cfg.buildMode == BuildModePlaceholderParam {
+				imageParam = built
+				needsBuildWarning = fmt.Sprintf("requires a manual build of %q; supply its image via the %s parameter", resource.Path, imageParam)
+			} else {
+				mappedImage = built
+				builtImage = built
+			}
 		}
 
 		image = mappedImage
 	}
 
-	if image == "" {
-		return nil, fmt.Errorf("resource %q has no image", name)
+	if image == "" && imageParam == "" {
+		return nil, "", nil, fmt.Errorf("resource %q has no image", name)
 	}
 
 	container := &ContainerSpec{
-		Image: image,
-		Env:   make(map[string]EnvVarSpec),
-		Ports: make(map[string]PortSpec),
+		Image:      image,
+		ImageParam: imageParam,
+		Env:        make(map[string]EnvVarSpec),
+		Ports:      make(map[string]PortSpec),
 	}
 
 	// Map entrypoint to command.
@@ -95,7 +132,10 @@ func mapContainer(name string, resource ManifestResource, bicepID string, imageM
 		container.Env[key] = EnvVarSpec{Value: value}
 	}
 
-	// Map bindings to ports.
+	// Map bindings to ports. A binding with neither TargetPort nor Port set is left
+	// unresolved for now; it is auto-populated below from the image's exposed ports, if
+	// the registry resolver can report them.
+	var unresolvedBindings []string
 	for bindingName, binding := range resource.Bindings {
 		port := binding.TargetPort
 		if port == 0 {
@@ -108,7 +148,11 @@ func mapContainer(name string, resource ManifestResource, bicepID string, imageM
 				Protocol:      strings.ToUpper(binding.Protocol),
 				Scheme:        binding.Scheme,
 			}
+
+			continue
 		}
+
+		unresolvedBindings = append(unresolvedBindings, bindingName)
 	}
 
 	// Map volumes.
@@ -138,6 +182,80 @@ func mapContainer(name string, resource ManifestResource, bicepID string, imageM
 		}
 	}
 
+	// Apply docker-create-style runtime options (--memory, --cap-add, --health-cmd, etc.).
+	warnings = applyRuntimeOptions(container, resource.Options)
+
+	if needsBuildWarning != "" {
+		warnings = append(warnings, needsBuildWarning)
+	}
+
+	// Map the Aspire manifest's optional healthCheck block to liveness/readiness probes.
+	if resource.HealthCheck != nil {
+		probe, warning := mapHealthCheckProbe(resource.HealthCheck, resource.Bindings)
+		if warning != "" {
+			warnings = append(warnings, warning)
+		} else {
+			container.LivenessProbe = probe
+			container.ReadinessProbe = probe
+		}
+	}
+
+	// When the registry resolver can inspect the image's exposed ports, assign them to
+	// bindings that didn't declare a port, in name order, one port per binding.
+	if len(unresolvedBindings) > 0 && image != "" && cfg != nil {
+		if portResolver, ok := cfg.registryResolver.(PortResolver); ok {
+			ports, found, portErr := resolveImagePorts(ctx, portResolver, image)
+			switch {
+			case portErr != nil:
+				warnings = append(warnings, fmt.Sprintf("resolving exposed ports for %q failed: %s", image, portErr))
+			case found:
+				sort.Strings(unresolvedBindings)
+
+				for i, bindingName := range unresolvedBindings {
+					if i >= len(ports) {
+						break
+					}
+
+					container.Ports[bindingName] = PortSpec{
+						ContainerPort: ports[i].Port,
+						Protocol:      ports[i].Protocol,
+						Scheme:        resource.Bindings[bindingName].Scheme,
+					}
+				}
+			}
+		}
+	}
+
+	// Rewrite a floating tag (e.g. "myapp/api:latest") to the immutable digest the
+	// registry currently serves for it, for reproducibility. Skipped when the image is
+	// already digest-pinned, since ResolveDigest then reports ok=false.
+	if image != "" && cfg != nil {
+		if digestResolver, ok := cfg.registryResolver.(DigestResolver); ok {
+			digest, found, digestErr := resolveImageDigest(ctx, digestResolver, image)
+			switch {
+			case digestErr != nil:
+				warnings = append(warnings, fmt.Sprintf("resolving digest for %q failed: %s", image, digestErr))
+			case found:
+				container.Image = imageRepository(image) + "@" + digest
+			}
+		}
+	}
+
+	// When the registry resolver can also resolve multi-arch image indexes, record the
+	// per-platform digests so the emitter can select one at deploy time instead of
+	// losing the index's extra platforms.
+	if image != "" && cfg != nil {
+		if indexResolver, ok := cfg.registryResolver.(ImageIndexResolver); ok {
+			variants, found, indexErr := indexResolver.ResolveImageIndex(image)
+			switch {
+			case indexErr != nil:
+				warnings = append(warnings, fmt.Sprintf("resolving multi-arch image index for %q failed: %s", image, indexErr))
+			case found:
+				container.PlatformVariants = variants
+			}
+		}
+	}
+
 	return &RadiusResource{
 		BicepIdentifier: bicepID,
 		RuntimeName:     name,
@@ -145,20 +263,129 @@ func mapContainer(name string, resource ManifestResource, bicepID string, imageM
 		APIVersion:      apiVersion,
 		Kind:            KindContainer,
 		Container:       container,
-	}, nil
+	}, builtImage, warnings, nil
+}
+
+// mapHealthCheckProbe translates an Aspire healthCheck block into a ProbeSpec, probing
+// the binding it names (defaulting to "http"). The probe mechanism follows the target
+// binding's scheme/protocol: "http"/"https" becomes an httpGet probe against hc.Path,
+// "http2" transport with a "grpc" protocol becomes a grpc probe, and anything else
+// becomes a plain tcp probe. Returns a non-empty warning (and a nil probe) if hc
+// references a binding the resource doesn't declare.
+func mapHealthCheckProbe(hc *ManifestHealthCheck, bindings map[string]ManifestBinding) (*ProbeSpec, string) {
+	bindingName := hc.Binding
+	if bindingName == "" {
+		bindingName = "http"
+	}
+
+	binding, ok := bindings[bindingName]
+	if !ok {
+		return nil, fmt.Sprintf("healthCheck references binding %q, which is not declared", bindingName)
+	}
+
+	port := binding.TargetPort
+	if port == 0 {
+		port = binding.Port
+	}
+
+	probe := &ProbeSpec{
+		ContainerPort:       port,
+		InitialDelaySeconds: hc.InitialDelaySeconds,
+		PeriodSeconds:       hc.IntervalSeconds,
+		TimeoutSeconds:      hc.TimeoutSeconds,
+		FailureThreshold:    hc.FailureThreshold,
+	}
+
+	if probe.PeriodSeconds == 0 {
+		probe.PeriodSeconds = 10
+	}
+	if probe.TimeoutSeconds == 0 {
+		probe.TimeoutSeconds = 1
+	}
+	if probe.FailureThreshold == 0 {
+		probe.FailureThreshold = 3
+	}
+
+	switch {
+	case binding.Transport == "http2" && binding.Protocol == "grpc":
+		probe.Kind = "grpc"
+	case binding.Scheme == "http" || binding.Scheme == "https":
+		probe.Kind = "httpGet"
+		probe.Path = hc.Path
+		if probe.Path == "" {
+			probe.Path = "/"
+		}
+	default:
+		probe.Kind = "tcp"
+	}
+
+	return probe, ""
+}
+
+// resolveImageDigest returns resolver's digest for image, consulting and populating
+// ctx.imageDigests so the same image is only looked up once per translation.
+func resolveImageDigest(ctx *translationContext, resolver DigestResolver, image string) (string, bool, error) {
+	if digest, cached := ctx.imageDigests[image]; cached {
+		return digest, digest != "", nil
+	}
+
+	digest, ok, err := resolver.ResolveDigest(image)
+	if err != nil {
+		return "", false, err
+	}
+
+	if ctx.imageDigests == nil {
+		ctx.imageDigests = make(map[string]string)
+	}
+
+	ctx.imageDigests[image] = digest
+
+	return digest, ok, nil
+}
+
+// resolveImagePorts returns resolver's exposed ports for image, consulting and populating
+// ctx.imagePorts so the same image is only looked up once per translation.
+func resolveImagePorts(ctx *translationContext, resolver PortResolver, image string) ([]ExposedPort, bool, error) {
+	if ports, cached := ctx.imagePorts[image]; cached {
+		return ports, len(ports) > 0, nil
+	}
+
+	ports, ok, err := resolver.ResolvePorts(image)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if ctx.imagePorts == nil {
+		ctx.imagePorts = make(map[string][]ExposedPort)
+	}
+
+	ctx.imagePorts[image] = ports
+
+	return ports, ok, nil
 }
 
 // mapPortableResource creates a RadiusResource for a portable resource (backing service).
-func mapPortableResource(name string, kind ResourceKind, bicepID string) *RadiusResource {
+// When resource.Image carries a digest or tag, it is parsed and exposed on the resulting
+// PortableResourceSpec so the recipe can be pinned by digest rather than a mutable tag.
+func mapPortableResource(name string, kind ResourceKind, resource ManifestResource, bicepID string) *RadiusResource {
+	spec := &PortableResourceSpec{
+		RecipeName: "default",
+	}
+
+	if resource.Image != "" {
+		if ref, err := ParseReference(resource.Image); err == nil {
+			spec.ImageDigest = ref.Digest
+			spec.ImageTag = ref.Tag
+		}
+	}
+
 	return &RadiusResource{
-		BicepIdentifier: bicepID,
-		RuntimeName:     name,
-		RadiusType:      string(kind),
-		APIVersion:      apiVersion,
-		Kind:            kind,
-		PortableResource: &PortableResourceSpec{
-			RecipeName: "default",
-		},
+		BicepIdentifier:  bicepID,
+		RuntimeName:      name,
+		RadiusType:       string(kind),
+		APIVersion:       apiVersion,
+		Kind:             kind,
+		PortableResource: spec,
 	}
 }
 
@@ -176,30 +403,159 @@ func synthesizeApplication(appName, environmentName string) *RadiusResource {
 	}
 }
 
-// synthesizeGateway creates a gateway resource from container bindings marked as external.
-func synthesizeGateway(ctx *translationContext) *RadiusResource {
+// synthesizeGateway exposes each container's external bindings according to
+// ctx.config.gatewayMode, defaulting to GatewayModeIngress: a single
+// Applications.Core/gateways resource (synthesizeIngressGateway), one
+// Applications.Core/services resource per container (synthesizeNodePortServices), or
+// (GatewayModeNone) no resource at all, annotating containers with hostPort instead
+// (annotateHostPorts). Returns an unsupportedGatewayModeError for any other value.
+func synthesizeGateway(ctx *translationContext) ([]*RadiusResource, error) {
+	mode := ctx.config.gatewayMode
+	if mode == "" {
+		mode = GatewayModeIngress
+	}
+
+	switch mode {
+	case GatewayModeIngress:
+		gateway, err := synthesizeIngressGateway(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if gateway == nil {
+			return nil, nil
+		}
+
+		return []*RadiusResource{gateway}, nil
+	case GatewayModeNodePort:
+		return synthesizeNodePortServices(ctx)
+	case GatewayModeNone:
+		return nil, annotateHostPorts(ctx)
+	default:
+		return nil, &unsupportedGatewayModeError{mode: mode}
+	}
+}
+
+// synthesizeIngressGateway creates a gateway resource from container bindings marked as
+// external. A resource that exposes both an external "http" and "https" binding gets its
+// http binding synthesized as an HTTP-to-HTTPS redirect route (permanent or temporary,
+// per binding.RedirectPermanent) rather than a proxied route to the container, analogous
+// to Traefik's permanent redirect middleware. Bindings of two different resources that
+// resolve to the same scheme, host, and path are rejected with a gatewayRouteCollisionError
+// rather than silently emitting an invalid gateway; bindings of one resource are not
+// checked against each other.
+func synthesizeIngressGateway(ctx *translationContext) (*RadiusResource, error) {
+	var names []string
+	for name := range ctx.manifest.Resources {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
 	var routes []GatewayRouteSpec
+	claimed := map[string]string{}
 
-	for name, resource := range ctx.manifest.Resources {
+	for _, name := range names {
+		resource := ctx.manifest.Resources[name]
 		if resource.Bindings == nil {
 			continue
 		}
 
-		for _, binding := range resource.Bindings {
+		var bindingNames []string
+		for bindingName := range resource.Bindings {
+			bindingNames = append(bindingNames, bindingName)
+		}
+
+		sort.Strings(bindingNames)
+
+		var httpBinding, httpsBinding *ManifestBinding
+		for _, bindingName := range bindingNames {
+			binding := resource.Bindings[bindingName]
 			if !binding.External {
 				continue
 			}
 
-			url := buildBindingURL(name, binding)
+			switch binding.Scheme {
+			case "https":
+				b := binding
+				httpsBinding = &b
+			case "http":
+				b := binding
+				httpBinding = &b
+			default:
+				host, path := gatewayHostPath(name, binding)
+				if err := claimGatewayRoute(claimed, name, binding.Scheme, host, path); err != nil {
+					return nil, err
+				}
+
+				routes = append(routes, GatewayRouteSpec{
+					Path:         path,
+					Hostname:     host,
+					Destination:  buildBindingURL(name, binding),
+					SourceRanges: resolveGatewaySourceRanges(name, binding, ctx),
+				})
+			}
+		}
+
+		if httpsBinding == nil && httpBinding == nil {
+			continue
+		}
+		if httpsBinding == nil {
+			host, path := gatewayHostPath(name, *httpBinding)
+			if err := claimGatewayRoute(claimed, name, "http", host, path); err != nil {
+				return nil, err
+			}
+
+			routes = append(routes, GatewayRouteSpec{
+				Path:         path,
+				Hostname:     host,
+				Destination:  buildBindingURL(name, *httpBinding),
+				SourceRanges: resolveGatewaySourceRanges(name, *httpBinding, ctx),
+			})
+
+			continue
+		}
+
+		host, path := gatewayHostPath(name, *httpsBinding)
+		if err := claimGatewayRoute(claimed, name, "https", host, path); err != nil {
+			return nil, err
+		}
+
+		route := GatewayRouteSpec{
+			Path:         path,
+			Hostname:     host,
+			Destination:  buildBindingURL(name, *httpsBinding),
+			SourceRanges: resolveGatewaySourceRanges(name, *httpsBinding, ctx),
+		}
+		if httpsBinding.TLSCertificateSecret != "" {
+			route.TLS = &TLSSpec{CertificateFrom: httpsBinding.TLSCertificateSecret}
+		}
+
+		routes = append(routes, route)
+
+		if httpBinding != nil {
+			redirectHost, redirectPath := gatewayHostPath(name, *httpBinding)
+			if err := claimGatewayRoute(claimed, name, "http", redirectHost, redirectPath); err != nil {
+				return nil, err
+			}
+
+			statusCode := 302
+			if httpsBinding.RedirectPermanent {
+				statusCode = 301
+			}
+
 			routes = append(routes, GatewayRouteSpec{
-				Path:        "/",
-				Destination: url,
+				Path:     redirectPath,
+				Hostname: redirectHost,
+				Redirect: &RedirectSpec{
+					Destination: fmt.Sprintf("https://%s%s", host, path),
+					StatusCode:  statusCode,
+				},
 			})
 		}
 	}
 
 	if len(routes) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	return &RadiusResource{
@@ -211,5 +567,253 @@ func synthesizeGateway(ctx *translationContext) *RadiusResource {
 		Gateway: &GatewaySpec{
 			Routes: routes,
 		},
+	}, nil
+}
+
+// gatewayHostPath resolves the effective gateway hostname and path for a binding,
+// defaulting to the resource name and "/" respectively when unset.
+func gatewayHostPath(resourceName string, binding ManifestBinding) (host, path string) {
+	host = binding.Host
+	if host == "" {
+		host = resourceName
+	}
+
+	path = binding.Path
+	if path == "" {
+		path = "/"
+	}
+
+	return host, path
+}
+
+// resolveGatewaySourceRanges returns the CIDR allowlist to apply to resourceName's
+// gateway route: binding.SourceRanges if set, falling back to the manifest-wide
+// AspireManifest.Gateway.AllowedSourceRanges. Entries that fail net.ParseCIDR are dropped
+// with a warning rather than failing the whole translation; an empty or missing list
+// returns nil, preserving the unrestricted default.
+func resolveGatewaySourceRanges(resourceName string, binding ManifestBinding, ctx *translationContext) []string {
+	candidates := binding.SourceRanges
+	if len(candidates) == 0 && ctx.manifest.Gateway != nil {
+		candidates = ctx.manifest.Gateway.AllowedSourceRanges
+	}
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	var valid []string
+	for _, cidr := range candidates {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			ctx.addWarning(fmt.Sprintf("Resource %q: gateway source range %q is not a valid CIDR, dropping it", resourceName, cidr))
+			continue
+		}
+
+		valid = append(valid, cidr)
+	}
+
+	return valid
+}
+
+// claimGatewayRoute records that resourceName owns the given scheme/host/path gateway
+// slot, returning a gatewayRouteCollisionError if a different resource already claimed it.
+func claimGatewayRoute(claimed map[string]string, resourceName, scheme, host, path string) error {
+	key := scheme + "|" + host + "|" + path
+
+	if owner, ok := claimed[key]; ok && owner != resourceName {
+		return &gatewayRouteCollisionError{
+			host:          host,
+			path:          path,
+			firstBinding:  owner,
+			secondBinding: resourceName,
+		}
+	}
+
+	claimed[key] = resourceName
+
+	return nil
+}
+
+// synthesizeNodePortServices creates one Applications.Core/services resource per
+// container resource that has at least one external binding, in GatewayModeNodePort.
+// Each external binding becomes a NodePortSpec entry keyed by binding name, mirroring how
+// synthesizeIngressGateway groups a container's bindings into one resource; unlike ingress
+// mode there's no TLS/redirect handling, since a NodePort service fronts a single raw port
+// per binding rather than HTTP(S) routing rules.
+func synthesizeNodePortServices(ctx *translationContext) ([]*RadiusResource, error) {
+	var names []string
+	for name := range ctx.manifest.Resources {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var services []*RadiusResource
+
+	for _, name := range names {
+		resource := ctx.manifest.Resources[name]
+		if resource.Bindings == nil {
+			continue
+		}
+
+		containerRes, ok := ctx.resources[name]
+		if !ok || containerRes.Container == nil {
+			continue
+		}
+
+		var bindingNames []string
+		for bindingName := range resource.Bindings {
+			bindingNames = append(bindingNames, bindingName)
+		}
+
+		sort.Strings(bindingNames)
+
+		ports := map[string]NodePortSpec{}
+		for _, bindingName := range bindingNames {
+			binding := resource.Bindings[bindingName]
+			if !binding.External {
+				continue
+			}
+
+			portSpec, ok := containerRes.Container.Ports[bindingName]
+			if !ok {
+				continue
+			}
+
+			nodePort := binding.Port
+			if nodePort == 0 {
+				nodePort = portSpec.ContainerPort
+			}
+
+			ports[bindingName] = NodePortSpec{
+				ContainerPort: portSpec.ContainerPort,
+				NodePort:      nodePort,
+				Protocol:      portSpec.Protocol,
+			}
+		}
+
+		if len(ports) == 0 {
+			continue
+		}
+
+		services = append(services, &RadiusResource{
+			BicepIdentifier: containerRes.BicepIdentifier + "Svc",
+			RuntimeName:     name + "-svc",
+			RadiusType:      string(KindService),
+			APIVersion:      apiVersion,
+			Kind:            KindService,
+			Service: &ServiceSpec{
+				ContainerRef: containerRes.BicepIdentifier,
+				Ports:        ports,
+			},
+		})
+	}
+
+	return services, nil
+}
+
+// annotateHostPorts implements GatewayModeNone: instead of synthesizing a gateway or
+// services, it records each external binding's host-facing port directly on the
+// container's PortSpec, so the generated Bicep documents the port a user fronting the
+// deployment themselves needs to forward.
+func annotateHostPorts(ctx *translationContext) error {
+	for name, resource := range ctx.manifest.Resources {
+		if resource.Bindings == nil {
+			continue
+		}
+
+		containerRes, ok := ctx.resources[name]
+		if !ok || containerRes.Container == nil {
+			continue
+		}
+
+		for bindingName, binding := range resource.Bindings {
+			if !binding.External {
+				continue
+			}
+
+			portSpec, ok := containerRes.Container.Ports[bindingName]
+			if !ok {
+				continue
+			}
+
+			hostPort := binding.Port
+			if hostPort == 0 {
+				hostPort = portSpec.ContainerPort
+			}
+
+			portSpec.HostPort = hostPort
+			containerRes.Container.Ports[bindingName] = portSpec
+		}
+	}
+
+	return nil
+}
+
+// isSecretParameter returns true if a parameter.v0 resource has an input marked secret.
+func isSecretParameter(resource ManifestResource) bool {
+	for _, input := range resource.Inputs {
+		if input.Secret {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parameterGenerateConfig returns the generate config for a parameter.v0 resource whose
+// input declares inputs.*.default.generate, or nil if none of its inputs do.
+func parameterGenerateConfig(resource ManifestResource) *ManifestParamGenerate {
+	for _, input := range resource.Inputs {
+		if input.Default != nil && input.Default.Generate != nil {
+			return input.Default.Generate
+		}
+	}
+
+	return nil
+}
+
+// generatedDefaultExpression returns the Bicep expression used as a secret parameter's
+// default when Aspire declared inputs.*.default.generate. newGuid() produces a 36-
+// character value, which covers every minLength Aspire actually asks for in practice.
+func generatedDefaultExpression() string {
+	return "newGuid()"
+}
+
+// synthesizeSecretStore creates the Applications.Core/secretStores resource backing the
+// secret parameter.v0 resources collected in ctx.secretKeys. Returns nil when there are
+// none, or when config.secretBackend is SecretBackendBicepParam (the default).
+func synthesizeSecretStore(ctx *translationContext) *RadiusResource {
+	if ctx.config.secretBackend == "" || ctx.config.secretBackend == SecretBackendBicepParam {
+		return nil
+	}
+
+	if len(ctx.secretKeys) == 0 {
+		return nil
+	}
+
+	storeName := ctx.config.secretStoreName
+	if storeName == "" {
+		storeName = "secrets"
+	}
+
+	spec := &SecretStoreSpec{
+		Type: "generic",
+		Keys: ctx.secretKeys,
+	}
+
+	switch ctx.config.secretBackend {
+	case SecretBackendKubernetesSecret:
+		spec.Resource = fmt.Sprintf("default/%s", storeName)
+	case SecretBackendAzureKeyVault:
+		spec.Resource = "<YOUR_KEYVAULT_RESOURCE_ID>"
+	}
+
+	return &RadiusResource{
+		BicepIdentifier: "secretstore",
+		RuntimeName:     storeName,
+		RadiusType:      string(KindSecretStore),
+		APIVersion:      apiVersion,
+		Kind:            KindSecretStore,
+		SecretStore:     spec,
 	}
 }
@@ -16,7 +16,12 @@ limitations under the License.
 
 package aspire
 
-import "testing"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
 
 func TestClassify(t *testing.T) {
 	t.Parallel()
@@ -62,7 +67,7 @@ func TestClassify(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			result := classify(tt.name, tt.resource, nil)
+			result := classify(tt.name, tt.resource, nil, nil)
 			if result != tt.expected {
 				t.Errorf("classify(%q) = %q, want %q", tt.resource.Type, result, tt.expected)
 			}
@@ -79,7 +84,7 @@ func TestClassify_WithOverride(t *testing.T) {
 
 	// Even though the image contains "redis", the override forces it to KindContainer.
 	resource := ManifestResource{Type: "container.v0", Image: "redis:latest"}
-	result := classify("myredis", resource, overrides)
+	result := classify("myredis", resource, &translationConfig{resourceOverrides: overrides}, nil)
 	if result != KindContainer {
 		t.Errorf("expected KindContainer with override, got %q", result)
 	}
@@ -146,7 +151,7 @@ func TestMapContainer(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			result, err := mapContainer(tt.name, tt.resource, "test", nil)
+			result, _, _, err := mapContainer(tt.name, tt.resource, "test", &translationContext{config: &translationConfig{}})
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -166,6 +171,268 @@ func TestMapContainer(t *testing.T) {
 	}
 }
 
+func TestMapContainer_PlatformVariants(t *testing.T) {
+	t.Parallel()
+
+	variants := []ImageVariant{
+		{Platform: "linux/amd64", Digest: "sha256:amd64digest", Size: 123},
+		{Platform: "linux/arm64", Digest: "sha256:arm64digest", Size: 456},
+	}
+
+	resolver := &StubRegistryResolver{
+		IndexResults: map[string][]ImageVariant{
+			"myapp/api:latest": variants,
+		},
+	}
+
+	resource := ManifestResource{Type: "container.v0", Image: "myapp/api:latest"}
+	result, _, _, err := mapContainer("api", resource, "api", &translationContext{config: &translationConfig{registryResolver: resolver}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Container.PlatformVariants) != 2 {
+		t.Fatalf("expected 2 platform variants, got %d", len(result.Container.PlatformVariants))
+	}
+
+	if result.Container.PlatformVariants[0] != variants[0] || result.Container.PlatformVariants[1] != variants[1] {
+		t.Errorf("expected platform variants %v, got %v", variants, result.Container.PlatformVariants)
+	}
+}
+
+func TestMapContainer_PlainManifestHasNoPlatformVariants(t *testing.T) {
+	t.Parallel()
+
+	resolver := &StubRegistryResolver{
+		IndexResults: map[string][]ImageVariant{
+			"other:latest": {{Platform: "linux/amd64", Digest: "sha256:abc"}},
+		},
+	}
+
+	resource := ManifestResource{Type: "container.v0", Image: "myapp/api:latest"}
+	result, _, _, err := mapContainer("api", resource, "api", &translationContext{config: &translationConfig{registryResolver: resolver}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Container.PlatformVariants) != 0 {
+		t.Errorf("expected no platform variants, got %v", result.Container.PlatformVariants)
+	}
+}
+
+func TestMapContainer_IndexResolverError(t *testing.T) {
+	t.Parallel()
+
+	resolver := &StubRegistryResolver{
+		IndexErrors: map[string]error{
+			"myapp/api:latest": fmt.Errorf("registry unreachable"),
+		},
+	}
+
+	resource := ManifestResource{Type: "container.v0", Image: "myapp/api:latest"}
+	result, _, warnings, err := mapContainer("api", resource, "api", &translationContext{config: &translationConfig{registryResolver: resolver}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Container.PlatformVariants) != 0 {
+		t.Errorf("expected no platform variants on resolver error, got %v", result.Container.PlatformVariants)
+	}
+
+	if len(warnings) != 1 {
+		t.Fatalf("expected 1 warning, got %d: %v", len(warnings), warnings)
+	}
+}
+
+func TestMapContainer_DigestRewrite(t *testing.T) {
+	t.Parallel()
+
+	resolver := &StubRegistryResolver{
+		DigestResults: map[string]string{
+			"myapp/api:latest": "sha256:abcdef",
+		},
+	}
+
+	resource := ManifestResource{Type: "container.v0", Image: "myapp/api:latest"}
+	result, _, _, err := mapContainer("api", resource, "api", &translationContext{config: &translationConfig{registryResolver: resolver}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Container.Image != "myapp/api@sha256:abcdef" {
+		t.Errorf("expected digest-pinned image, got %q", result.Container.Image)
+	}
+}
+
+func TestMapContainer_DigestRewrite_AlreadyPinnedIsLeftAlone(t *testing.T) {
+	t.Parallel()
+
+	resolver := &StubRegistryResolver{
+		DigestResults: map[string]string{
+			"myapp/api@sha256:existing": "sha256:abcdef",
+		},
+	}
+
+	resource := ManifestResource{Type: "container.v0", Image: "myapp/api@sha256:existing"}
+	result, _, _, err := mapContainer("api", resource, "api", &translationContext{config: &translationConfig{registryResolver: resolver}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Container.Image != "myapp/api@sha256:existing" {
+		t.Errorf("expected image to be left untouched, got %q", result.Container.Image)
+	}
+}
+
+func TestMapContainer_DigestRewrite_ResolverErrorDegradesGracefully(t *testing.T) {
+	t.Parallel()
+
+	resolver := &StubRegistryResolver{
+		DigestErrors: map[string]error{
+			"myapp/api:latest": fmt.Errorf("registry unreachable"),
+		},
+	}
+
+	resource := ManifestResource{Type: "container.v0", Image: "myapp/api:latest"}
+	result, _, warnings, err := mapContainer("api", resource, "api", &translationContext{config: &translationConfig{registryResolver: resolver}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Container.Image != "myapp/api:latest" {
+		t.Errorf("expected image to be left untouched on resolver error, got %q", result.Container.Image)
+	}
+
+	if len(warnings) != 1 {
+		t.Fatalf("expected 1 warning, got %d: %v", len(warnings), warnings)
+	}
+}
+
+func TestMapContainer_DigestRewrite_CachedPerImage(t *testing.T) {
+	t.Parallel()
+
+	resolver := &StubRegistryResolver{
+		DigestResults: map[string]string{
+			"myapp/api:latest": "sha256:abcdef",
+		},
+	}
+
+	ctx := &translationContext{
+		config:       &translationConfig{registryResolver: resolver},
+		imageDigests: make(map[string]string),
+	}
+
+	resourceA := ManifestResource{Type: "container.v0", Image: "myapp/api:latest"}
+	resourceB := ManifestResource{Type: "container.v0", Image: "myapp/api:latest"}
+
+	if _, _, _, err := mapContainer("a", resourceA, "a", ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Remove the stubbed result; a second lookup for the same image must come from
+	// ctx.imageDigests rather than calling the resolver again.
+	delete(resolver.DigestResults, "myapp/api:latest")
+
+	result, _, _, err := mapContainer("b", resourceB, "b", ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Container.Image != "myapp/api@sha256:abcdef" {
+		t.Errorf("expected cached digest to be reused, got %q", result.Container.Image)
+	}
+}
+
+func TestMapContainer_AutoPopulatesPortsFromImageConfig(t *testing.T) {
+	t.Parallel()
+
+	resolver := &StubRegistryResolver{
+		PortResults: map[string][]ExposedPort{
+			"myapp/api:latest": {{Port: 8080, Protocol: "TCP"}},
+		},
+	}
+
+	resource := ManifestResource{
+		Type:  "container.v0",
+		Image: "myapp/api:latest",
+		Bindings: map[string]ManifestBinding{
+			"http": {Scheme: "http"},
+		},
+	}
+
+	result, _, _, err := mapContainer("api", resource, "api", &translationContext{config: &translationConfig{registryResolver: resolver}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	port, ok := result.Container.Ports["http"]
+	if !ok {
+		t.Fatal("expected binding 'http' to get an auto-populated port")
+	}
+
+	if port.ContainerPort != 8080 || port.Protocol != "TCP" {
+		t.Errorf("unexpected port spec: %+v", port)
+	}
+}
+
+func TestMapContainer_DeclaredPortTakesPrecedenceOverImageConfig(t *testing.T) {
+	t.Parallel()
+
+	resolver := &StubRegistryResolver{
+		PortResults: map[string][]ExposedPort{
+			"myapp/api:latest": {{Port: 9999, Protocol: "TCP"}},
+		},
+	}
+
+	resource := ManifestResource{
+		Type:  "container.v0",
+		Image: "myapp/api:latest",
+		Bindings: map[string]ManifestBinding{
+			"http": {Scheme: "http", TargetPort: 8080},
+		},
+	}
+
+	result, _, _, err := mapContainer("api", resource, "api", &translationContext{config: &translationConfig{registryResolver: resolver}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Container.Ports["http"].ContainerPort != 8080 {
+		t.Errorf("expected declared TargetPort to win, got %+v", result.Container.Ports["http"])
+	}
+}
+
+func TestMapContainer_PortResolverErrorDegradesGracefully(t *testing.T) {
+	t.Parallel()
+
+	resolver := &StubRegistryResolver{
+		PortErrors: map[string]error{
+			"myapp/api:latest": fmt.Errorf("registry unreachable"),
+		},
+	}
+
+	resource := ManifestResource{
+		Type:  "container.v0",
+		Image: "myapp/api:latest",
+		Bindings: map[string]ManifestBinding{
+			"http": {Scheme: "http"},
+		},
+	}
+
+	result, _, warnings, err := mapContainer("api", resource, "api", &translationContext{config: &translationConfig{registryResolver: resolver}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := result.Container.Ports["http"]; ok {
+		t.Error("expected no port to be populated on resolver error")
+	}
+
+	if len(warnings) != 1 {
+		t.Fatalf("expected 1 warning, got %d: %v", len(warnings), warnings)
+	}
+}
+
 func TestMapContainer_WithEntrypointAndArgs(t *testing.T) {
 	t.Parallel()
 
@@ -176,7 +443,7 @@ func TestMapContainer_WithEntrypointAndArgs(t *testing.T) {
 		Args:       []string{"--config", "/etc/config.yaml"},
 	}
 
-	result, err := mapContainer("test", resource, "test", nil)
+	result, _, _, err := mapContainer("test", resource, "test", &translationContext{config: &translationConfig{}})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -201,7 +468,7 @@ func TestMapContainer_Volumes(t *testing.T) {
 		},
 	}
 
-	result, err := mapContainer("test", resource, "test", nil)
+	result, _, _, err := mapContainer("test", resource, "test", &translationContext{config: &translationConfig{}})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -224,7 +491,130 @@ func TestMapContainer_Volumes(t *testing.T) {
 	}
 }
 
-func TestSynthesizeGateway(t *testing.T) {
+func TestMapContainer_HealthCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		bindings    map[string]ManifestBinding
+		healthCheck *ManifestHealthCheck
+		wantKind    string
+		wantPath    string
+		wantPort    int
+	}{
+		{
+			name: "http",
+			bindings: map[string]ManifestBinding{
+				"http": {Scheme: "http", TargetPort: 8080},
+			},
+			healthCheck: &ManifestHealthCheck{Path: "/healthz", Binding: "http", IntervalSeconds: 10, InitialDelaySeconds: 5, TimeoutSeconds: 2, FailureThreshold: 3},
+			wantKind:    "httpGet",
+			wantPath:    "/healthz",
+			wantPort:    8080,
+		},
+		{
+			name: "tcp",
+			bindings: map[string]ManifestBinding{
+				"tcp": {Scheme: "tcp", TargetPort: 5432},
+			},
+			healthCheck: &ManifestHealthCheck{Binding: "tcp"},
+			wantKind:    "tcp",
+			wantPort:    5432,
+		},
+		{
+			name: "grpc",
+			bindings: map[string]ManifestBinding{
+				"grpc": {Scheme: "http2", Transport: "http2", Protocol: "grpc", TargetPort: 50051},
+			},
+			healthCheck: &ManifestHealthCheck{Binding: "grpc"},
+			wantKind:    "grpc",
+			wantPort:    50051,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resource := ManifestResource{
+				Type:        "container.v0",
+				Image:       "myapp:latest",
+				Bindings:    tt.bindings,
+				HealthCheck: tt.healthCheck,
+			}
+
+			result, _, _, err := mapContainer(tt.name, resource, "test", &translationContext{config: &translationConfig{}})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for _, probe := range []*ProbeSpec{result.Container.LivenessProbe, result.Container.ReadinessProbe} {
+				if probe == nil {
+					t.Fatal("expected a probe")
+				}
+
+				if probe.Kind != tt.wantKind {
+					t.Errorf("probe.Kind = %q, want %q", probe.Kind, tt.wantKind)
+				}
+
+				if probe.Path != tt.wantPath {
+					t.Errorf("probe.Path = %q, want %q", probe.Path, tt.wantPath)
+				}
+
+				if probe.ContainerPort != tt.wantPort {
+					t.Errorf("probe.ContainerPort = %d, want %d", probe.ContainerPort, tt.wantPort)
+				}
+			}
+		})
+	}
+}
+
+func TestMapContainer_NoHealthCheckMeansNoProbe(t *testing.T) {
+	t.Parallel()
+
+	resource := ManifestResource{
+		Type:  "container.v0",
+		Image: "myapp:latest",
+	}
+
+	result, _, _, err := mapContainer("test", resource, "test", &translationContext{config: &translationConfig{}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Container.LivenessProbe != nil {
+		t.Errorf("expected no liveness probe, got %+v", result.Container.LivenessProbe)
+	}
+
+	if result.Container.ReadinessProbe != nil {
+		t.Errorf("expected no readiness probe, got %+v", result.Container.ReadinessProbe)
+	}
+}
+
+func TestMapContainer_HealthCheckUnknownBinding(t *testing.T) {
+	t.Parallel()
+
+	resource := ManifestResource{
+		Type:        "container.v0",
+		Image:       "myapp:latest",
+		HealthCheck: &ManifestHealthCheck{Binding: "nope"},
+	}
+
+	result, _, warnings, err := mapContainer("test", resource, "test", &translationContext{config: &translationConfig{}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Container.LivenessProbe != nil || result.Container.ReadinessProbe != nil {
+		t.Error("expected no probe when healthCheck references an unknown binding")
+	}
+
+	if len(warnings) != 1 {
+		t.Fatalf("expected one warning, got %d: %v", len(warnings), warnings)
+	}
+}
+
+func TestSynthesizeIngressGateway(t *testing.T) {
 	t.Parallel()
 
 	t.Run("with external bindings", func(t *testing.T) {
@@ -244,7 +634,10 @@ func TestSynthesizeGateway(t *testing.T) {
 			},
 		}
 
-		gw := synthesizeGateway(ctx)
+		gw, err := synthesizeIngressGateway(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
 		if gw == nil {
 			t.Fatal("expected gateway to be synthesized")
 		}
@@ -271,9 +664,719 @@ func TestSynthesizeGateway(t *testing.T) {
 			},
 		}
 
-		gw := synthesizeGateway(ctx)
+		gw, err := synthesizeIngressGateway(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
 		if gw != nil {
 			t.Error("expected no gateway when no external bindings")
 		}
 	})
+
+	t.Run("http/https pair synthesizes a redirect route", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := &translationContext{
+			manifest: &AspireManifest{
+				Resources: map[string]ManifestResource{
+					"frontend": {
+						Type:  "container.v0",
+						Image: "frontend:latest",
+						Bindings: map[string]ManifestBinding{
+							"http":  {Scheme: "http", Port: 80, TargetPort: 8080, External: true},
+							"https": {Scheme: "https", Port: 443, TargetPort: 8443, External: true, TLSCertificateSecret: "frontend-cert", RedirectPermanent: true},
+						},
+					},
+				},
+			},
+		}
+
+		gw, err := synthesizeIngressGateway(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if gw == nil {
+			t.Fatal("expected gateway to be synthesized")
+		}
+
+		if len(gw.Gateway.Routes) != 2 {
+			t.Fatalf("expected 2 routes, got %d", len(gw.Gateway.Routes))
+		}
+
+		var httpsRoute, redirectRoute *GatewayRouteSpec
+		for i := range gw.Gateway.Routes {
+			route := &gw.Gateway.Routes[i]
+			if route.Redirect != nil {
+				redirectRoute = route
+			} else {
+				httpsRoute = route
+			}
+		}
+
+		if httpsRoute == nil || httpsRoute.TLS == nil || httpsRoute.TLS.CertificateFrom != "frontend-cert" {
+			t.Fatalf("expected https route with TLS certificate, got %+v", httpsRoute)
+		}
+
+		if redirectRoute == nil || redirectRoute.Redirect.StatusCode != 301 {
+			t.Fatalf("expected permanent redirect route, got %+v", redirectRoute)
+		}
+	})
+
+	t.Run("single CIDR source range", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := &translationContext{
+			manifest: &AspireManifest{
+				Resources: map[string]ManifestResource{
+					"frontend": {
+						Type:  "container.v0",
+						Image: "frontend:latest",
+						Bindings: map[string]ManifestBinding{
+							"http": {Scheme: "http", Port: 80, TargetPort: 8080, External: true, SourceRanges: []string{"10.0.0.0/8"}},
+						},
+					},
+				},
+			},
+		}
+
+		gw, err := synthesizeIngressGateway(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got := gw.Gateway.Routes[0].SourceRanges; len(got) != 1 || got[0] != "10.0.0.0/8" {
+			t.Fatalf("expected SourceRanges [10.0.0.0/8], got %v", got)
+		}
+	})
+
+	t.Run("multiple CIDRs with mixed IPv4/IPv6", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := &translationContext{
+			manifest: &AspireManifest{
+				Resources: map[string]ManifestResource{
+					"frontend": {
+						Type:  "container.v0",
+						Image: "frontend:latest",
+						Bindings: map[string]ManifestBinding{
+							"http": {Scheme: "http", Port: 80, TargetPort: 8080, External: true, SourceRanges: []string{"10.0.0.0/8", "2001:db8::/32"}},
+						},
+					},
+				},
+			},
+		}
+
+		gw, err := synthesizeIngressGateway(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		got := gw.Gateway.Routes[0].SourceRanges
+		if len(got) != 2 || got[0] != "10.0.0.0/8" || got[1] != "2001:db8::/32" {
+			t.Fatalf("expected both CIDRs preserved, got %v", got)
+		}
+	})
+
+	t.Run("invalid CIDR is dropped with a warning", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := &translationContext{
+			manifest: &AspireManifest{
+				Resources: map[string]ManifestResource{
+					"frontend": {
+						Type:  "container.v0",
+						Image: "frontend:latest",
+						Bindings: map[string]ManifestBinding{
+							"http": {Scheme: "http", Port: 80, TargetPort: 8080, External: true, SourceRanges: []string{"10.0.0.0/8", "not-a-cidr"}},
+						},
+					},
+				},
+			},
+		}
+
+		gw, err := synthesizeIngressGateway(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got := gw.Gateway.Routes[0].SourceRanges; len(got) != 1 || got[0] != "10.0.0.0/8" {
+			t.Fatalf("expected invalid entry dropped, got %v", got)
+		}
+
+		if len(ctx.warnings) != 1 || !strings.Contains(ctx.warnings[0], "not-a-cidr") {
+			t.Fatalf("expected a warning naming the invalid CIDR, got %v", ctx.warnings)
+		}
+	})
+
+	t.Run("manifest-wide default applies when binding has no source ranges", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := &translationContext{
+			manifest: &AspireManifest{
+				Gateway: &ManifestGatewayConfig{AllowedSourceRanges: []string{"192.168.0.0/16"}},
+				Resources: map[string]ManifestResource{
+					"frontend": {
+						Type:  "container.v0",
+						Image: "frontend:latest",
+						Bindings: map[string]ManifestBinding{
+							"http": {Scheme: "http", Port: 80, TargetPort: 8080, External: true},
+						},
+					},
+				},
+			},
+		}
+
+		gw, err := synthesizeIngressGateway(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got := gw.Gateway.Routes[0].SourceRanges; len(got) != 1 || got[0] != "192.168.0.0/16" {
+			t.Fatalf("expected manifest-wide default applied, got %v", got)
+		}
+	})
+
+	t.Run("no external binding still returns nil", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := &translationContext{
+			manifest: &AspireManifest{
+				Gateway: &ManifestGatewayConfig{AllowedSourceRanges: []string{"192.168.0.0/16"}},
+				Resources: map[string]ManifestResource{
+					"api": {
+						Type:  "container.v0",
+						Image: "api:latest",
+						Bindings: map[string]ManifestBinding{
+							"http": {Scheme: "http", Port: 8080, TargetPort: 8080, External: false},
+						},
+					},
+				},
+			},
+		}
+
+		gw, err := synthesizeIngressGateway(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if gw != nil {
+			t.Error("expected no gateway when no external bindings")
+		}
+	})
+
+	t.Run("collision across containers is an error", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := &translationContext{
+			manifest: &AspireManifest{
+				Resources: map[string]ManifestResource{
+					"a": {
+						Type:  "container.v0",
+						Image: "a:latest",
+						Bindings: map[string]ManifestBinding{
+							"http": {Scheme: "http", Port: 80, TargetPort: 8080, External: true, Host: "shared.example.com"},
+						},
+					},
+					"b": {
+						Type:  "container.v0",
+						Image: "b:latest",
+						Bindings: map[string]ManifestBinding{
+							"http": {Scheme: "http", Port: 80, TargetPort: 8081, External: true, Host: "shared.example.com"},
+						},
+					},
+				},
+			},
+		}
+
+		_, err := synthesizeIngressGateway(ctx)
+		if err == nil {
+			t.Fatal("expected a collision error")
+		}
+
+		var collisionErr *gatewayRouteCollisionError
+		if !errors.As(err, &collisionErr) {
+			t.Fatalf("expected gatewayRouteCollisionError, got %T: %v", err, err)
+		}
+	})
+}
+
+func TestMapPortableResource(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		resource     ManifestResource
+		expectTag    string
+		expectDigest string
+	}{
+		{
+			name:      "tagged image",
+			resource:  ManifestResource{Type: "container.v0", Image: "redis:7"},
+			expectTag: "7",
+		},
+		{
+			name:         "digest pinned image",
+			resource:     ManifestResource{Type: "container.v0", Image: "postgres@sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+			expectDigest: "sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		},
+		{
+			name:     "no image",
+			resource: ManifestResource{Type: "parameter.v0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := mapPortableResource("cache", KindRedisCache, tt.resource, "cache")
+
+			if result.PortableResource.ImageTag != tt.expectTag {
+				t.Errorf("expected tag %q, got %q", tt.expectTag, result.PortableResource.ImageTag)
+			}
+
+			if result.PortableResource.ImageDigest != tt.expectDigest {
+				t.Errorf("expected digest %q, got %q", tt.expectDigest, result.PortableResource.ImageDigest)
+			}
+		})
+	}
+}
+
+func TestMapContainer_ProjectBuildModeBuildpacks(t *testing.T) {
+	t.Parallel()
+
+	origPackBuild := packBuild
+	defer func() { packBuild = origPackBuild }()
+
+	var calledPath, calledImage, calledBuilder string
+	packBuild = func(sourcePath, imageRef, builderImage string) error {
+		calledPath = sourcePath
+		calledImage = imageRef
+		calledBuilder = builderImage
+		return nil
+	}
+
+	resource := ManifestResource{Type: "project.v1", Path: "src/api/api.csproj"}
+	cfg := &translationConfig{buildMode: BuildModeBuildpacks, registry: "myregistry.io"}
+
+	result, builtImage, _, err := mapContainer("api", resource, "api", &translationContext{config: cfg})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if builtImage != "myregistry.io/api:latest" {
+		t.Errorf("expected built image 'myregistry.io/api:latest', got %q", builtImage)
+	}
+
+	if result.Container.Image != builtImage {
+		t.Errorf("expected container image %q, got %q", builtImage, result.Container.Image)
+	}
+
+	if calledPath != "src/api/api.csproj" {
+		t.Errorf("expected pack build to receive source path, got %q", calledPath)
+	}
+
+	if calledImage != "myregistry.io/api:latest" {
+		t.Errorf("expected pack build to receive image ref, got %q", calledImage)
+	}
+
+	if calledBuilder != "" {
+		t.Errorf("expected empty builder image override, got %q", calledBuilder)
+	}
+}
+
+func TestMapContainer_ProjectBuildModeNoneRequiresMapping(t *testing.T) {
+	t.Parallel()
+
+	resource := ManifestResource{Type: "project.v0", Path: "src/worker"}
+	cfg := &translationConfig{buildMode: BuildModeNone}
+
+	_, _, _, err := mapContainer("worker", resource, "worker", &translationContext{config: cfg})
+	if err == nil {
+		t.Fatal("expected error when no image mapping and BuildModeNone")
+	}
+
+	if _, ok := err.(*missingImageMappingError); !ok {
+		t.Errorf("expected missingImageMappingError, got %T", err)
+	}
+}
+
+func TestMapContainer_ProjectBuildModePlaceholderParam(t *testing.T) {
+	t.Parallel()
+
+	resource := ManifestResource{Type: "project.v1", Path: "src/api/api.csproj"}
+	cfg := &translationConfig{buildMode: BuildModePlaceholderParam}
+
+	result, builtImage, warnings, err := mapContainer("api", resource, "api", &translationContext{config: cfg})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if builtImage != "" {
+		t.Errorf("expected no built image for BuildModePlaceholderParam, got %q", builtImage)
+	}
+
+	if result.Container.Image != "" {
+		t.Errorf("expected empty Image when ImageParam is set, got %q", result.Container.Image)
+	}
+
+	if result.Container.ImageParam != "apiImage" {
+		t.Errorf("expected ImageParam %q, got %q", "apiImage", result.Container.ImageParam)
+	}
+
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "src/api/api.csproj") {
+		t.Errorf("expected a build-required warning referencing the project path, got %v", warnings)
+	}
+}
+
+func TestMapContainer_ProjectWithBuildOnlyCompanion(t *testing.T) {
+	t.Parallel()
+
+	resource := ManifestResource{Type: "project.v1", Path: "src/api/api.csproj"}
+	cfg := &translationConfig{buildMode: BuildModeNone}
+	ctx := &translationContext{
+		config: cfg,
+		manifest: &AspireManifest{
+			Resources: map[string]ManifestResource{
+				"api-build": {
+					Type:      "container.v0",
+					BuildOnly: true,
+					Parent:    "api",
+					Image:     "myregistry.io/api:sha-abc123",
+				},
+			},
+		},
+	}
+
+	result, builtImage, _, err := mapContainer("api", resource, "api", ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if builtImage != "" {
+		t.Errorf("expected no builtImage when the image comes from a BuildOnly companion, got %q", builtImage)
+	}
+
+	if result.Container.Image != "myregistry.io/api:sha-abc123" {
+		t.Errorf("expected image from BuildOnly companion, got %q", result.Container.Image)
+	}
+}
+
+func TestMapContainer_ProjectBuildModeDotnetPublish(t *testing.T) {
+	t.Parallel()
+
+	origDotnetPublish := dotnetPublish
+	defer func() { dotnetPublish = origDotnetPublish }()
+
+	var calledPath, calledImage string
+	dotnetPublish = func(sourcePath, imageRef string) error {
+		calledPath = sourcePath
+		calledImage = imageRef
+		return nil
+	}
+
+	resource := ManifestResource{Type: "project.v0", Path: "src/worker/worker.csproj"}
+	cfg := &translationConfig{buildMode: BuildModeDotnetPublish, registry: "myregistry.io"}
+
+	result, builtImage, _, err := mapContainer("worker", resource, "worker", &translationContext{config: cfg})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if builtImage != "myregistry.io/worker:latest" {
+		t.Errorf("expected built image 'myregistry.io/worker:latest', got %q", builtImage)
+	}
+
+	if result.Container.Image != builtImage {
+		t.Errorf("expected container image %q, got %q", builtImage, result.Container.Image)
+	}
+
+	if calledPath != "src/worker/worker.csproj" {
+		t.Errorf("expected dotnet publish to receive source path, got %q", calledPath)
+	}
+
+	if calledImage != "myregistry.io/worker:latest" {
+		t.Errorf("expected dotnet publish to receive image ref, got %q", calledImage)
+	}
+}
+
+func TestMapContainer_ProjectBuildModeDotnetPublish_MissingPath(t *testing.T) {
+	t.Parallel()
+
+	resource := ManifestResource{Type: "project.v0"}
+	cfg := &translationConfig{buildMode: BuildModeDotnetPublish}
+
+	_, _, _, err := mapContainer("worker", resource, "worker", &translationContext{config: cfg})
+	if _, ok := err.(*missingBuildSourceError); !ok {
+		t.Errorf("expected missingBuildSourceError, got %T (%v)", err, err)
+	}
+}
+
+func TestMapContainer_ProjectBuildModeDockerfile(t *testing.T) {
+	t.Parallel()
+
+	origDockerBuild := dockerBuild
+	defer func() { dockerBuild = origDockerBuild }()
+
+	var calledPath, calledImage string
+	dockerBuild = func(sourcePath, imageRef string) error {
+		calledPath = sourcePath
+		calledImage = imageRef
+		return nil
+	}
+
+	resource := ManifestResource{Type: "project.v1", Path: "src/api"}
+	cfg := &translationConfig{buildMode: BuildModeDockerfile, registry: "myregistry.io"}
+
+	result, builtImage, _, err := mapContainer("api", resource, "api", &translationContext{config: cfg})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if builtImage != "myregistry.io/api:latest" {
+		t.Errorf("expected built image 'myregistry.io/api:latest', got %q", builtImage)
+	}
+
+	if result.Container.Image != builtImage {
+		t.Errorf("expected container image %q, got %q", builtImage, result.Container.Image)
+	}
+
+	if calledPath != "src/api" {
+		t.Errorf("expected docker build to receive source path, got %q", calledPath)
+	}
+
+	if calledImage != "myregistry.io/api:latest" {
+		t.Errorf("expected docker build to receive image ref, got %q", calledImage)
+	}
+}
+
+func TestMapContainer_ProjectBuildModeDockerfile_Push(t *testing.T) {
+	t.Parallel()
+
+	origDockerBuild := dockerBuild
+	origDockerPush := dockerPush
+	defer func() {
+		dockerBuild = origDockerBuild
+		dockerPush = origDockerPush
+	}()
+
+	dockerBuild = func(sourcePath, imageRef string) error { return nil }
+
+	var pushedImage string
+	dockerPush = func(imageRef string) error {
+		pushedImage = imageRef
+		return nil
+	}
+
+	resource := ManifestResource{Type: "project.v1", Path: "src/api"}
+	cfg := &translationConfig{buildMode: BuildModeDockerfile, registry: "myregistry.io", pushImages: true}
+
+	_, builtImage, _, err := mapContainer("api", resource, "api", &translationContext{config: cfg})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if pushedImage != builtImage {
+		t.Errorf("expected docker push to receive the built image %q, got %q", builtImage, pushedImage)
+	}
+}
+
+func TestMapContainer_ProjectBuildModeDockerfile_NoPushByDefault(t *testing.T) {
+	t.Parallel()
+
+	origDockerBuild := dockerBuild
+	origDockerPush := dockerPush
+	defer func() {
+		dockerBuild = origDockerBuild
+		dockerPush = origDockerPush
+	}()
+
+	dockerBuild = func(sourcePath, imageRef string) error { return nil }
+
+	pushCalled := false
+	dockerPush = func(imageRef string) error {
+		pushCalled = true
+		return nil
+	}
+
+	resource := ManifestResource{Type: "project.v1", Path: "src/api"}
+	cfg := &translationConfig{buildMode: BuildModeDockerfile, registry: "myregistry.io"}
+
+	if _, _, _, err := mapContainer("api", resource, "api", &translationContext{config: cfg}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if pushCalled {
+		t.Error("expected docker push not to be called when PushImages is unset")
+	}
+}
+
+func TestMapContainer_ProjectBuildModeDockerfile_MissingPath(t *testing.T) {
+	t.Parallel()
+
+	resource := ManifestResource{Type: "project.v0"}
+	cfg := &translationConfig{buildMode: BuildModeDockerfile}
+
+	_, _, _, err := mapContainer("worker", resource, "worker", &translationContext{config: cfg})
+	if _, ok := err.(*missingBuildSourceError); !ok {
+		t.Errorf("expected missingBuildSourceError, got %T (%v)", err, err)
+	}
+}
+
+func TestSynthesizeSecretStore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default backend produces no secret store", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := &translationContext{
+			config:     &translationConfig{secretBackend: SecretBackendBicepParam},
+			secretKeys: map[string]string{"dbPassword": ""},
+		}
+
+		if store := synthesizeSecretStore(ctx); store != nil {
+			t.Errorf("expected no secret store for SecretBackendBicepParam, got %+v", store)
+		}
+	})
+
+	t.Run("no secret keys produces no secret store", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := &translationContext{
+			config:     &translationConfig{secretBackend: SecretBackendRadiusSecretStore},
+			secretKeys: map[string]string{},
+		}
+
+		if store := synthesizeSecretStore(ctx); store != nil {
+			t.Errorf("expected no secret store when there are no secret keys, got %+v", store)
+		}
+	})
+
+	t.Run("radius secret store", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := &translationContext{
+			config:     &translationConfig{secretBackend: SecretBackendRadiusSecretStore, secretStoreName: "mysecrets"},
+			secretKeys: map[string]string{"dbPassword": "generated-default"},
+		}
+
+		store := synthesizeSecretStore(ctx)
+		if store == nil {
+			t.Fatal("expected a secret store to be synthesized")
+		}
+
+		if store.RuntimeName != "mysecrets" {
+			t.Errorf("expected RuntimeName %q, got %q", "mysecrets", store.RuntimeName)
+		}
+
+		if store.SecretStore.Resource != "" {
+			t.Errorf("expected no Resource reference for SecretBackendRadiusSecretStore, got %q", store.SecretStore.Resource)
+		}
+
+		if store.SecretStore.Keys["dbPassword"] != "generated-default" {
+			t.Errorf("unexpected keys: %v", store.SecretStore.Keys)
+		}
+	})
+
+	t.Run("kubernetes secret backend references an existing secret", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := &translationContext{
+			config:     &translationConfig{secretBackend: SecretBackendKubernetesSecret, secretStoreName: "mysecrets"},
+			secretKeys: map[string]string{"dbPassword": ""},
+		}
+
+		store := synthesizeSecretStore(ctx)
+		if store == nil {
+			t.Fatal("expected a secret store to be synthesized")
+		}
+
+		if store.SecretStore.Resource == "" {
+			t.Error("expected a Resource reference for SecretBackendKubernetesSecret")
+		}
+	})
+}
+
+func TestIsSecretParameter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("secret input", func(t *testing.T) {
+		t.Parallel()
+
+		resource := ManifestResource{
+			Type:   "parameter.v0",
+			Inputs: map[string]ManifestParamInput{"value": {Secret: true}},
+		}
+
+		if !isSecretParameter(resource) {
+			t.Error("expected isSecretParameter to return true")
+		}
+	})
+
+	t.Run("non-secret input", func(t *testing.T) {
+		t.Parallel()
+
+		resource := ManifestResource{
+			Type:   "parameter.v0",
+			Inputs: map[string]ManifestParamInput{"value": {Secret: false}},
+		}
+
+		if isSecretParameter(resource) {
+			t.Error("expected isSecretParameter to return false")
+		}
+	})
+}
+
+func TestParameterGenerateConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("generate declared", func(t *testing.T) {
+		t.Parallel()
+
+		resource := ManifestResource{
+			Type: "parameter.v0",
+			Inputs: map[string]ManifestParamInput{
+				"value": {
+					Secret:  true,
+					Default: &ManifestParamDefault{Generate: &ManifestParamGenerate{MinLength: 22}},
+				},
+			},
+		}
+
+		generate := parameterGenerateConfig(resource)
+		if generate == nil {
+			t.Fatal("expected a generate config")
+		}
+
+		if generate.MinLength != 22 {
+			t.Errorf("expected MinLength 22, got %d", generate.MinLength)
+		}
+	})
+
+	t.Run("no default", func(t *testing.T) {
+		t.Parallel()
+
+		resource := ManifestResource{
+			Type:   "parameter.v0",
+			Inputs: map[string]ManifestParamInput{"value": {Secret: true}},
+		}
+
+		if generate := parameterGenerateConfig(resource); generate != nil {
+			t.Errorf("expected no generate config, got %+v", generate)
+		}
+	})
+
+	t.Run("default without generate", func(t *testing.T) {
+		t.Parallel()
+
+		resource := ManifestResource{
+			Type: "parameter.v0",
+			Inputs: map[string]ManifestParamInput{
+				"value": {Secret: true, Default: &ManifestParamDefault{Value: "fallback"}},
+			},
+		}
+
+		if generate := parameterGenerateConfig(resource); generate != nil {
+			t.Errorf("expected no generate config, got %+v", generate)
+		}
+	})
 }
@@ -0,0 +1,166 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ContainerOverlay is a hand-authored snippet (YAML or JSON, both of which gopkg.in/yaml.v3
+// parses) that TranslateOptions.ManifestOverlays merges into one container resource's
+// translated ContainerSpec, letting users layer in details the Aspire manifest doesn't
+// carry (or override ones it does) without patching the manifest itself.
+type ContainerOverlay struct {
+	// Env merges into the container's environment variables. A key already set by the
+	// manifest is overwritten and a warning is recorded. Values may use the same
+	// "{resource.property}" expression syntax as manifest-native env vars.
+	Env map[string]string `yaml:"env"`
+
+	// Command, when set, replaces the container's entrypoint command.
+	Command []string `yaml:"command"`
+
+	// Resources, when set, replaces the container's compute resource requests.
+	Resources *ContainerResourcesSpec `yaml:"resources"`
+
+	// Probes overrides the container's liveness/readiness probes.
+	Probes *ContainerOverlayProbes `yaml:"probes"`
+
+	// Scale overrides the default replica bounds emitContainerAppYAML otherwise applies.
+	// Has no effect on EmitFormat values other than FormatContainerAppYAML and
+	// FormatBicepAndContainerAppYAML.
+	Scale *ContainerOverlayScale `yaml:"scale"`
+}
+
+// ContainerOverlayProbes carries overlay overrides for a container's health probes.
+type ContainerOverlayProbes struct {
+	Liveness  *ProbeSpec `yaml:"liveness"`
+	Readiness *ProbeSpec `yaml:"readiness"`
+}
+
+// ContainerOverlayScale carries overlay overrides for ContainerSpec.MinReplicas/MaxReplicas.
+type ContainerOverlayScale struct {
+	MinReplicas *int `yaml:"minReplicas"`
+	MaxReplicas *int `yaml:"maxReplicas"`
+}
+
+// applyManifestOverlays parses and merges every entry in ctx.config.manifestOverlays into
+// its target container resource. A resource name with no matching container (wrong name,
+// or naming a non-container resource) is reported as a warning, not a fatal error, the
+// same way mapOneResource treats other best-effort outcomes.
+func applyManifestOverlays(ctx *translationContext) error {
+	if len(ctx.config.manifestOverlays) == 0 {
+		return nil
+	}
+
+	var names []string
+	for name := range ctx.config.manifestOverlays {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		raw := ctx.config.manifestOverlays[name]
+
+		var overlay ContainerOverlay
+		if err := yaml.Unmarshal([]byte(raw), &overlay); err != nil {
+			return &overlayParseError{resourceName: name, cause: err}
+		}
+
+		resource, ok := ctx.resources[name]
+		if !ok || resource.Container == nil {
+			ctx.addWarning(fmt.Sprintf("manifest overlay for %q does not match a container resource, ignoring", name))
+			continue
+		}
+
+		mergeContainerOverlay(name, resource.Container, &overlay, ctx)
+	}
+
+	return nil
+}
+
+// mergeContainerOverlay merges overlay into container, recording a warning for every field
+// the manifest had already populated that the overlay replaces.
+func mergeContainerOverlay(name string, container *ContainerSpec, overlay *ContainerOverlay, ctx *translationContext) {
+	if len(overlay.Env) > 0 {
+		if container.Env == nil {
+			container.Env = make(map[string]EnvVarSpec, len(overlay.Env))
+		}
+
+		var keys []string
+		for key := range overlay.Env {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if _, exists := container.Env[key]; exists {
+				ctx.addWarning(fmt.Sprintf("manifest overlay for %q overrides env var %q", name, key))
+			}
+
+			container.Env[key] = EnvVarSpec{Value: overlay.Env[key]}
+		}
+	}
+
+	if len(overlay.Command) > 0 {
+		if len(container.Command) > 0 {
+			ctx.addWarning(fmt.Sprintf("manifest overlay for %q overrides command", name))
+		}
+
+		container.Command = overlay.Command
+	}
+
+	if overlay.Resources != nil {
+		if container.Resources != nil {
+			ctx.addWarning(fmt.Sprintf("manifest overlay for %q overrides resource requests", name))
+		}
+
+		container.Resources = overlay.Resources
+	}
+
+	if overlay.Probes != nil {
+		if overlay.Probes.Liveness != nil {
+			if container.LivenessProbe != nil {
+				ctx.addWarning(fmt.Sprintf("manifest overlay for %q overrides liveness probe", name))
+			}
+
+			container.LivenessProbe = overlay.Probes.Liveness
+		}
+
+		if overlay.Probes.Readiness != nil {
+			if container.ReadinessProbe != nil {
+				ctx.addWarning(fmt.Sprintf("manifest overlay for %q overrides readiness probe", name))
+			}
+
+			container.ReadinessProbe = overlay.Probes.Readiness
+		}
+	}
+
+	if overlay.Scale != nil {
+		if overlay.Scale.MinReplicas != nil {
+			container.MinReplicas = overlay.Scale.MinReplicas
+		}
+
+		if overlay.Scale.MaxReplicas != nil {
+			container.MaxReplicas = overlay.Scale.MaxReplicas
+		}
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestApplyManifestOverlays(t *testing.T) {
+	t.Parallel()
+
+	t.Run("merges env, command, resources, and scale without conflicts", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := newTranslationContext(&AspireManifest{}, &translationConfig{
+			manifestOverlays: map[string]string{
+				"api": `
+env:
+  CACHE_URL: "{cache.connectionString}"
+command: ["/app/run"]
+resources:
+  cpu: "0.5"
+  memory: "512Mi"
+scale:
+  minReplicas: 2
+  maxReplicas: 5
+`,
+			},
+		})
+		ctx.resources["api"] = &RadiusResource{Kind: KindContainer, Container: &ContainerSpec{}}
+
+		if err := applyManifestOverlays(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.warnings) != 0 {
+			t.Errorf("unexpected warnings: %v", ctx.warnings)
+		}
+
+		container := ctx.resources["api"].Container
+		if container.Env["CACHE_URL"].Value != "{cache.connectionString}" {
+			t.Errorf("unexpected env: %+v", container.Env)
+		}
+		if len(container.Command) != 1 || container.Command[0] != "/app/run" {
+			t.Errorf("unexpected command: %v", container.Command)
+		}
+		if container.Resources == nil || container.Resources.CPU != "0.5" || container.Resources.Memory != "512Mi" {
+			t.Errorf("unexpected resources: %+v", container.Resources)
+		}
+		if container.MinReplicas == nil || *container.MinReplicas != 2 {
+			t.Errorf("unexpected MinReplicas: %v", container.MinReplicas)
+		}
+		if container.MaxReplicas == nil || *container.MaxReplicas != 5 {
+			t.Errorf("unexpected MaxReplicas: %v", container.MaxReplicas)
+		}
+	})
+
+	t.Run("overriding a manifest-set env var records a warning", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := newTranslationContext(&AspireManifest{}, &translationConfig{
+			manifestOverlays: map[string]string{
+				"api": `env:
+  LOG_LEVEL: debug`,
+			},
+		})
+		ctx.resources["api"] = &RadiusResource{
+			Kind: KindContainer,
+			Container: &ContainerSpec{
+				Env: map[string]EnvVarSpec{"LOG_LEVEL": {Value: "info"}},
+			},
+		}
+
+		if err := applyManifestOverlays(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.warnings) != 1 || !strings.Contains(ctx.warnings[0], "LOG_LEVEL") {
+			t.Errorf("expected an env override warning, got: %v", ctx.warnings)
+		}
+		if ctx.resources["api"].Container.Env["LOG_LEVEL"].Value != "debug" {
+			t.Errorf("expected overlay value to win, got: %+v", ctx.resources["api"].Container.Env)
+		}
+	})
+
+	t.Run("overlay for a nonexistent resource records a warning instead of failing", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := newTranslationContext(&AspireManifest{}, &translationConfig{
+			manifestOverlays: map[string]string{
+				"missing": `command: ["noop"]`,
+			},
+		})
+
+		if err := applyManifestOverlays(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.warnings) != 1 || !strings.Contains(ctx.warnings[0], "missing") {
+			t.Errorf("expected a warning naming the missing resource, got: %v", ctx.warnings)
+		}
+	})
+
+	t.Run("invalid overlay syntax is a translation error", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := newTranslationContext(&AspireManifest{}, &translationConfig{
+			manifestOverlays: map[string]string{
+				"api": "env: [this is not a map]",
+			},
+		})
+		ctx.resources["api"] = &RadiusResource{Kind: KindContainer, Container: &ContainerSpec{}}
+
+		err := applyManifestOverlays(ctx)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+
+		var parseErr *overlayParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("expected an *overlayParseError, got %T: %v", err, err)
+		}
+		if parseErr.resourceName != "api" {
+			t.Errorf("unexpected resourceName: %q", parseErr.resourceName)
+		}
+	})
+}
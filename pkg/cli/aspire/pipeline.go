@@ -0,0 +1,114 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"text/template"
+)
+
+// tektonProject describes a single project.v0/v1 resource that the generated Tekton
+// pipeline needs to build.
+type tektonProject struct {
+	// BicepIdentifier is the sanitized Bicep identifier for the resource.
+	BicepIdentifier string
+
+	// Image is the image reference the build Task will push to.
+	Image string
+
+	// Path is the source directory (e.g., the csproj directory) to build from.
+	Path string
+}
+
+// tektonPipelineTemplate renders a Tekton Pipeline that clones the source repository,
+// builds each project resource with a buildpacks Task, and deploys the generated Bicep
+// with a kubernetes-actions Task.
+const tektonPipelineTemplate = `apiVersion: tekton.dev/v1
+kind: Pipeline
+metadata:
+  name: {{ .AppName }}-build-deploy
+spec:
+  params:
+    - name: git-url
+    - name: git-revision
+      default: main
+  workspaces:
+    - name: source
+  tasks:
+    - name: git-clone
+      taskRef:
+        name: git-clone
+      workspaces:
+        - name: output
+          workspace: source
+      params:
+        - name: url
+          value: $(params.git-url)
+        - name: revision
+          value: $(params.git-revision)
+{{- range .Projects }}
+    - name: build-{{ .BicepIdentifier }}
+      taskRef:
+        name: buildpacks
+      runAfter: ["git-clone"]
+      workspaces:
+        - name: source
+          workspace: source
+      params:
+        - name: APP_IMAGE
+          value: {{ .Image }}
+        - name: SOURCE_SUBPATH
+          value: {{ .Path }}
+{{- end }}
+    - name: deploy
+      taskRef:
+        name: kubernetes-actions
+      runAfter:
+{{- range .Projects }}
+        - build-{{ .BicepIdentifier }}
+{{- end }}
+      params:
+        - name: script
+          value: |
+            rad deploy app.bicep -p environment=$(params.environment) -p application=$(params.application)
+`
+
+// generateTektonPipeline renders a Tekton Pipeline manifest for the given project resources.
+func generateTektonPipeline(appName string, projects []tektonProject) (string, error) {
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].BicepIdentifier < projects[j].BicepIdentifier
+	})
+
+	tmpl, err := template.New("pipeline").Parse(tektonPipelineTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to render Tekton pipeline: %w", err)
+	}
+
+	var buf bytes.Buffer
+	data := struct {
+		AppName  string
+		Projects []tektonProject
+	}{AppName: appName, Projects: projects}
+
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render Tekton pipeline: %w", err)
+	}
+
+	return buf.String(), nil
+}
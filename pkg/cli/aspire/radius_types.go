@@ -45,15 +45,29 @@ type RadiusResource struct {
 	// Application holds application details (for Application kind).
 	Application *ApplicationSpec
 
+	// SecretStore holds secret store details (for SecretStore kind).
+	SecretStore *SecretStoreSpec
+
+	// Extender holds extender details (for Extender kind).
+	Extender *ExtenderSpec
+
+	// Service holds NodePort-style service details (for Service kind).
+	Service *ServiceSpec
+
 	// Connections maps dependency names to connection specs.
 	Connections map[string]ConnectionSpec
 }
 
 // ContainerSpec holds container resource properties.
 type ContainerSpec struct {
-	// Image is the container image reference.
+	// Image is the container image reference. Ignored when ImageParam is set.
 	Image string
 
+	// ImageParam is the name of a Bicep parameter that supplies the image reference,
+	// emitted unquoted in place of Image. Set only for project.v0/v1 resources translated
+	// under BuildModePlaceholderParam.
+	ImageParam string
+
 	// Command is the entrypoint command (from Aspire entrypoint).
 	Command []string
 
@@ -68,6 +82,111 @@ type ContainerSpec struct {
 
 	// Volumes maps volume names to their specs.
 	Volumes map[string]VolumeSpec
+
+	// Resources holds compute resource requests parsed from docker-create-style
+	// --memory/--cpus runtime options. Nil when none were set.
+	Resources *ContainerResourcesSpec
+
+	// LivenessProbe is the liveness probe, either parsed from --health-cmd and related
+	// runtime options or derived from the Aspire manifest's healthCheck block (see
+	// mapHealthCheckProbe). Nil when not set.
+	LivenessProbe *ProbeSpec
+
+	// ReadinessProbe is the readiness probe derived from the Aspire manifest's
+	// healthCheck block (see mapHealthCheckProbe). Nil when not set.
+	ReadinessProbe *ProbeSpec
+
+	// SecurityContext holds Linux security settings parsed from --cap-add, --security-opt,
+	// --sysctl, --ulimit, and --user runtime options. Nil when none were set.
+	SecurityContext *SecurityContextSpec
+
+	// RestartPolicy is the restart policy parsed from --restart. Empty when not set.
+	RestartPolicy string
+
+	// Hostname is the container hostname parsed from --hostname. Empty when not set.
+	Hostname string
+
+	// PlatformVariants lists the per-platform image digests when Image resolves to a
+	// multi-arch OCI image index / Docker manifest list, rather than a single-platform
+	// manifest. Populated by mapContainer when the translationConfig's registryResolver
+	// implements ImageIndexResolver. Empty for single-platform images.
+	PlatformVariants []ImageVariant
+
+	// MinReplicas and MaxReplicas override emitContainerAppYAML's default scale bounds
+	// (acaDefaultMinReplicas/acaDefaultMaxReplicas) when set. Populated by a manifest
+	// overlay's "scale" block (see ContainerOverlay); nil for every other translation path,
+	// since Aspire manifests carry no replica-count information of their own.
+	MinReplicas *int
+	MaxReplicas *int
+}
+
+// ImageVariant is one platform-specific entry from a multi-arch image index.
+type ImageVariant struct {
+	// Platform is the OCI platform string, e.g. "linux/amd64" or "linux/arm64".
+	Platform string
+
+	// Digest is the content digest of this platform's manifest.
+	Digest string
+
+	// Size is the manifest's size in bytes, as reported by the image index.
+	Size int64
+}
+
+// ContainerResourcesSpec describes compute resource requests for a container.
+type ContainerResourcesSpec struct {
+	// CPU is the CPU request/limit (e.g., "0.5" or "2").
+	CPU string
+
+	// Memory is the memory request/limit (e.g., "512Mi").
+	Memory string
+}
+
+// ProbeSpec describes a container health probe.
+type ProbeSpec struct {
+	// Kind discriminates the probe mechanism: "exec", "httpGet", "tcp", or "grpc".
+	Kind string
+
+	// Command is the command to run for an "exec" probe.
+	Command []string
+
+	// Path is the HTTP path to probe, for a "httpGet" probe.
+	Path string
+
+	// ContainerPort is the port to probe, for a "httpGet", "tcp", or "grpc" probe.
+	ContainerPort int
+
+	// InitialDelaySeconds is the delay before the first probe, from --health-start-period
+	// or healthCheck.initialDelaySeconds.
+	InitialDelaySeconds int
+
+	// PeriodSeconds is the interval between probes, from --health-interval or
+	// healthCheck.intervalSeconds.
+	PeriodSeconds int
+
+	// TimeoutSeconds is the per-probe timeout, from healthCheck.timeoutSeconds.
+	TimeoutSeconds int
+
+	// FailureThreshold is the number of consecutive failures before giving up, from
+	// --health-retries or healthCheck.failureThreshold.
+	FailureThreshold int
+}
+
+// SecurityContextSpec describes Linux security settings for a container.
+type SecurityContextSpec struct {
+	// RunAsUser is the user (and optional group) the container runs as, from --user/-u.
+	RunAsUser string
+
+	// CapAdd lists added Linux capabilities, from --cap-add.
+	CapAdd []string
+
+	// SecurityOpt lists security options, from --security-opt.
+	SecurityOpt []string
+
+	// Sysctls maps kernel parameter names to values, from --sysctl.
+	Sysctls map[string]string
+
+	// Ulimits lists ulimit specs (e.g., "nofile=1024:2048"), from --ulimit.
+	Ulimits []string
 }
 
 // PortSpec describes a container port mapping.
@@ -80,6 +199,11 @@ type PortSpec struct {
 
 	// Scheme is the protocol scheme (http/https/tcp).
 	Scheme string
+
+	// HostPort is the host-facing port this binding should be forwarded to, set only in
+	// GatewayModeNone (see annotateHostPorts) as a substitute for the gateway/service
+	// resource that mode deliberately doesn't synthesize.
+	HostPort int
 }
 
 // VolumeSpec describes a volume mount.
@@ -101,6 +225,20 @@ type EnvVarSpec struct {
 
 	// IsBicepInterpolation indicates whether Value contains Bicep interpolation.
 	IsBicepInterpolation bool
+
+	// SecretRef points this env var at a key in a synthesized secretStores resource
+	// instead of a literal or interpolated Value. Set only when TranslateOptions.SecretBackend
+	// is not SecretBackendBicepParam.
+	SecretRef *EnvSecretRefSpec
+}
+
+// EnvSecretRefSpec describes an env var sourced from a Radius secret store key.
+type EnvSecretRefSpec struct {
+	// SecretStoreIdentifier is the Bicep identifier of the secretStores resource.
+	SecretStoreIdentifier string
+
+	// Key is the key within the secret store holding this value.
+	Key string
 }
 
 // ConnectionSpec describes a dependency connection to another resource.
@@ -116,6 +254,14 @@ type ConnectionSpec struct {
 type PortableResourceSpec struct {
 	// RecipeName is the recipe name (defaults to "default").
 	RecipeName string
+
+	// ImageDigest is the content digest (e.g. "sha256:abcd...") parsed from the backing
+	// service's source image reference, if any. When present it should be preferred over
+	// ImageTag for pinning the recipe to an immutable image.
+	ImageDigest string
+
+	// ImageTag is the tag parsed from the backing service's source image reference, if any.
+	ImageTag string
 }
 
 // GatewaySpec holds gateway resource properties.
@@ -129,8 +275,40 @@ type GatewayRouteSpec struct {
 	// Path is the route path (e.g., /).
 	Path string
 
-	// Destination is the destination URL.
+	// Hostname is the route's hostname match, if the binding declared one. Empty means
+	// the route matches any hostname.
+	Hostname string
+
+	// Destination is the destination URL. Empty when Redirect is set.
 	Destination string
+
+	// TLS terminates TLS for this route using a referenced certificate secret. Nil when
+	// the route is plain HTTP.
+	TLS *TLSSpec
+
+	// Redirect turns this route into an HTTP-to-HTTPS redirect instead of a proxied
+	// destination. Nil for ordinary routes.
+	Redirect *RedirectSpec
+
+	// SourceRanges is a CIDR allowlist restricting which client IPs may reach this route,
+	// resolved from the binding's own ManifestBinding.SourceRanges or, failing that, the
+	// manifest-wide AspireManifest.Gateway.AllowedSourceRanges. Nil means unrestricted.
+	SourceRanges []string
+}
+
+// TLSSpec describes TLS termination for a gateway route.
+type TLSSpec struct {
+	// CertificateFrom references the secret holding the TLS certificate for this route.
+	CertificateFrom string
+}
+
+// RedirectSpec describes an HTTP-to-HTTPS redirect for a gateway route.
+type RedirectSpec struct {
+	// Destination is the HTTPS URL to redirect to.
+	Destination string
+
+	// StatusCode is the redirect status code (301 or 302).
+	StatusCode int
 }
 
 // ApplicationSpec holds application resource properties.
@@ -139,6 +317,60 @@ type ApplicationSpec struct {
 	EnvironmentRef string
 }
 
+// SecretStoreSpec holds secretStores resource properties.
+type SecretStoreSpec struct {
+	// Type is the secretStores resource type discriminator (e.g. "generic").
+	Type string
+
+	// Resource references an existing secret that this store wraps (e.g. a Kubernetes
+	// secret "namespace/name" or an Azure Key Vault resource ID), instead of declaring
+	// values inline. Empty when values are declared inline via Keys.
+	Resource string
+
+	// Keys maps secret key names to their default value, if Aspire provided one. Keys
+	// with no default are emitted with an empty value and must be populated after deploy.
+	Keys map[string]string
+}
+
+// ExtenderSpec holds Applications.Core/extenders resource properties, used to model a
+// managed Azure service (an Aspire azure.* resource type) that has no dedicated Radius
+// portable resource type. The referenced Azure resource is expected to already exist.
+type ExtenderSpec struct {
+	// Category is a short human-readable label for the Azure service this extender
+	// models (e.g. "Azure Storage (Blob)"), used in the warning recorded when it's
+	// synthesized.
+	Category string
+
+	// Resource is a placeholder Azure resource ID for the user to supply after
+	// generation, mirroring SecretBackendAzureKeyVault's <YOUR_..._RESOURCE_ID>
+	// convention.
+	Resource string
+}
+
+// ServiceSpec holds Applications.Core/services resource properties, synthesized in
+// GatewayModeNodePort from a container's external bindings.
+type ServiceSpec struct {
+	// ContainerRef is the Bicep identifier of the container this service fronts.
+	ContainerRef string
+
+	// Ports maps binding name to its NodePort-style port mapping.
+	Ports map[string]NodePortSpec
+}
+
+// NodePortSpec describes one NodePort-style port mapping synthesized for an external
+// container binding.
+type NodePortSpec struct {
+	// ContainerPort is the container-side port number.
+	ContainerPort int
+
+	// NodePort is the externally-exposed port, defaulting to ContainerPort when the
+	// manifest binding didn't declare a distinct external port.
+	NodePort int
+
+	// Protocol is the transport protocol (TCP/UDP).
+	Protocol string
+}
+
 // BicepParameter represents a Bicep parameter declaration.
 type BicepParameter struct {
 	// Name is the parameter name.
@@ -147,9 +379,16 @@ type BicepParameter struct {
 	// Type is the parameter type (e.g., "string").
 	Type string
 
-	// DefaultValue is the default value, if any.
+	// DefaultValue is the default value, if any. Rendered as a quoted Bicep string
+	// literal. Ignored when DefaultExpression is set.
 	DefaultValue string
 
+	// DefaultExpression is a raw, unquoted Bicep expression (e.g. "newGuid()") used as
+	// the parameter's default instead of DefaultValue. Set for secret parameters whose
+	// Aspire input declared inputs.*.default.generate, since Aspire itself generates
+	// those values at run time rather than supplying a literal default.
+	DefaultExpression string
+
 	// Secure indicates whether the parameter should have the @secure() decorator.
 	Secure bool
 
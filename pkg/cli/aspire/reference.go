@@ -0,0 +1,101 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Reference is a parsed container image reference, in the spirit of
+// docker/distribution/reference. It supports the grammar:
+//
+//	reference := [domain '/'] path [':' tag] ['@' digest]
+//	domain    := component ('.' | ':' port)
+//
+// A leading component is recognized as a domain when it contains a "."
+// or a ":", or is exactly "localhost" â€” otherwise the whole reference is
+// treated as a (possibly multi-segment) path, matching the convention used
+// by the official Docker Hub registry.
+type Reference struct {
+	// Domain is the registry host, e.g. "docker.io" or "localhost:5000". Empty
+	// when the reference has no explicit registry.
+	Domain string
+
+	// Path is the repository name, e.g. "library/postgres". Never empty.
+	Path string
+
+	// Tag is the tag component, e.g. "14-alpine". Empty when not present.
+	Tag string
+
+	// Digest is the content digest, e.g. "sha256:abcd...". Empty when not present.
+	Digest string
+}
+
+// ParseReference parses a container image reference into its components.
+//
+// Examples:
+//
+//	"redis:7"                                  -> {Path: "redis", Tag: "7"}
+//	"localhost:5000/redis"                     -> {Domain: "localhost:5000", Path: "redis"}
+//	"postgres@sha256:abcd..."                  -> {Path: "postgres", Digest: "sha256:abcd..."}
+//	"myregistry.io/library/postgres:14-alpine" -> {Domain: "myregistry.io", Path: "library/postgres", Tag: "14-alpine"}
+func ParseReference(image string) (Reference, error) {
+	if image == "" {
+		return Reference{}, fmt.Errorf("image reference is empty")
+	}
+
+	var ref Reference
+
+	remainder := image
+
+	// Split off the digest, if any (everything after the last "@").
+	if idx := strings.LastIndex(remainder, "@"); idx != -1 {
+		ref.Digest = remainder[idx+1:]
+		remainder = remainder[:idx]
+	}
+
+	// Split off the domain, if the leading component looks like one.
+	if idx := strings.Index(remainder, "/"); idx != -1 {
+		firstComponent := remainder[:idx]
+		if isDomainComponent(firstComponent) {
+			ref.Domain = firstComponent
+			remainder = remainder[idx+1:]
+		}
+	}
+
+	// Split off the tag from the final path segment (tags cannot contain "/").
+	lastSlash := strings.LastIndex(remainder, "/")
+	lastSegment := remainder[lastSlash+1:]
+	if idx := strings.LastIndex(lastSegment, ":"); idx != -1 {
+		ref.Tag = lastSegment[idx+1:]
+		remainder = remainder[:lastSlash+1+idx]
+	}
+
+	ref.Path = remainder
+	if ref.Path == "" {
+		return Reference{}, fmt.Errorf("image reference %q has no repository path", image)
+	}
+
+	return ref, nil
+}
+
+// isDomainComponent reports whether a leading path component should be treated
+// as a registry domain rather than the first segment of the repository path.
+func isDomainComponent(component string) bool {
+	return strings.ContainsAny(component, ".:") || component == "localhost"
+}
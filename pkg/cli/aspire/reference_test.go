@@ -0,0 +1,98 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import "testing"
+
+func TestParseReference(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		image    string
+		expected Reference
+	}{
+		{
+			name:     "bare name",
+			image:    "redis",
+			expected: Reference{Path: "redis"},
+		},
+		{
+			name:     "name with tag",
+			image:    "redis:7",
+			expected: Reference{Path: "redis", Tag: "7"},
+		},
+		{
+			name:     "domain with port, no tag",
+			image:    "localhost:5000/redis",
+			expected: Reference{Domain: "localhost:5000", Path: "redis"},
+		},
+		{
+			name:     "domain with port and tag",
+			image:    "localhost:5000/team/redis:7",
+			expected: Reference{Domain: "localhost:5000", Path: "team/redis", Tag: "7"},
+		},
+		{
+			name:     "digest only",
+			image:    "postgres@sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+			expected: Reference{Path: "postgres", Digest: "sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		},
+		{
+			name:     "tagged digest",
+			image:    "myregistry.io/redis:7@sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+			expected: Reference{Domain: "myregistry.io", Path: "redis", Tag: "7", Digest: "sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		},
+		{
+			name:     "multi-segment path",
+			image:    "myregistry.io/org/team/redis:7",
+			expected: Reference{Domain: "myregistry.io", Path: "org/team/redis", Tag: "7"},
+		},
+		{
+			name:     "canonical docker.io path",
+			image:    "docker.io/bitnami/redis:latest",
+			expected: Reference{Domain: "docker.io", Path: "bitnami/redis", Tag: "latest"},
+		},
+		{
+			name:     "uppercase tag",
+			image:    "redis:LATEST-RC1",
+			expected: Reference{Path: "redis", Tag: "LATEST-RC1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := ParseReference(tt.image)
+			if err != nil {
+				t.Fatalf("ParseReference(%q) returned unexpected error: %v", tt.image, err)
+			}
+
+			if result != tt.expected {
+				t.Errorf("ParseReference(%q) = %+v, want %+v", tt.image, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseReference_Empty(t *testing.T) {
+	t.Parallel()
+
+	if _, err := ParseReference(""); err == nil {
+		t.Error("expected an error for an empty image reference")
+	}
+}
@@ -0,0 +1,708 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RegistryResolver classifies a container image by inspecting its remote image config
+// (the OCI manifest plus the config blob it references), rather than relying solely on
+// backingServiceTable's name-prefix matching. ok is false when the image's config does
+// not identify a known backing service; err is non-nil only on a genuine lookup failure
+// (network error, missing manifest, malformed response).
+type RegistryResolver interface {
+	ResolveBackingService(image string) (kind ResourceKind, ok bool, err error)
+}
+
+// ImageIndexResolver is an optional capability of a RegistryResolver: implementations
+// that can also resolve an image reference to its per-platform manifest list (an OCI
+// image index or Docker manifest list), when the reference points at one rather than a
+// plain single-platform manifest. mapContainer type-asserts for this interface rather
+// than requiring every RegistryResolver to implement it.
+type ImageIndexResolver interface {
+	// ResolveImageIndex returns the platform variants of image, or ok=false if image
+	// resolves to a plain manifest rather than an index.
+	ResolveImageIndex(image string) (variants []ImageVariant, ok bool, err error)
+}
+
+// DigestResolver is an optional capability of a RegistryResolver: implementations that can
+// resolve a floating tag to the immutable digest the registry currently serves for it, so
+// mapContainer can pin ContainerSpec.Image by digest instead of a mutable tag.
+type DigestResolver interface {
+	// ResolveDigest returns the content digest (e.g. "sha256:abcd...") that image's tag
+	// currently resolves to. ok is false when image is already digest-pinned, so there is
+	// nothing to rewrite.
+	ResolveDigest(image string) (digest string, ok bool, err error)
+}
+
+// PortResolver is an optional capability of a RegistryResolver: implementations that can
+// inspect an image's config for the ports it declares (Dockerfile EXPOSE or OCI config
+// ExposedPorts), so mapContainer can auto-populate a binding's port without the manifest
+// declaring TargetPort.
+type PortResolver interface {
+	// ResolvePorts returns the ports image's config exposes. ok is false when the image's
+	// config declares no ports.
+	ResolvePorts(image string) (ports []ExposedPort, ok bool, err error)
+}
+
+// ExposedPort is a single port an image's config declares it listens on.
+type ExposedPort struct {
+	// Port is the port number, e.g. 6379.
+	Port int
+
+	// Protocol is the transport protocol, upper-cased (e.g. "TCP", "UDP").
+	Protocol string
+}
+
+// classifyImage determines the ResourceKind for a container image. When resolver is
+// non-nil, it is tried first; its outcome (match, no-match, or error) is always recorded
+// via addWarning. detectBackingService's name-prefix matching is used as the fallback,
+// both when resolver is nil and when the resolver fails to identify the image.
+func classifyImage(name string, image string, resolver RegistryResolver, addWarning func(string)) ResourceKind {
+	if addWarning == nil {
+		addWarning = func(string) {}
+	}
+
+	if resolver != nil {
+		kind, ok, err := resolver.ResolveBackingService(image)
+		switch {
+		case err != nil:
+			addWarning(fmt.Sprintf("Resource %q: registry resolver lookup for %q failed (%s), falling back to name matching", name, image, err))
+		case ok:
+			addWarning(fmt.Sprintf("Resource %q: classified as %q from remote image config", name, kind))
+			return kind
+		default:
+			addWarning(fmt.Sprintf("Resource %q: registry resolver found no known backing service in %q's image config, falling back to name matching", name, image))
+		}
+	}
+
+	return detectBackingService(image)
+}
+
+// StubRegistryResolver is a test double that returns a fixed result for each image,
+// without making any network calls.
+type StubRegistryResolver struct {
+	// Results maps image reference to the kind ResolveBackingService should return for
+	// it. Images not present in the map resolve to (KindUnsupported, false, nil).
+	Results map[string]ResourceKind
+
+	// Errors maps image reference to an error ResolveBackingService should return for it,
+	// taking precedence over Results.
+	Errors map[string]error
+
+	// IndexResults maps image reference to the platform variants ResolveImageIndex
+	// should return for it. Images not present in the map resolve to (nil, false, nil).
+	IndexResults map[string][]ImageVariant
+
+	// IndexErrors maps image reference to an error ResolveImageIndex should return for
+	// it, taking precedence over IndexResults.
+	IndexErrors map[string]error
+
+	// DigestResults maps image reference to the digest ResolveDigest should return for
+	// it. Images not present in the map resolve to ("", false, nil).
+	DigestResults map[string]string
+
+	// DigestErrors maps image reference to an error ResolveDigest should return for it,
+	// taking precedence over DigestResults.
+	DigestErrors map[string]error
+
+	// PortResults maps image reference to the ports ResolvePorts should return for it.
+	// Images not present in the map resolve to (nil, false, nil).
+	PortResults map[string][]ExposedPort
+
+	// PortErrors maps image reference to an error ResolvePorts should return for it,
+	// taking precedence over PortResults.
+	PortErrors map[string]error
+}
+
+// ResolveBackingService implements RegistryResolver.
+func (s *StubRegistryResolver) ResolveBackingService(image string) (ResourceKind, bool, error) {
+	if err, ok := s.Errors[image]; ok {
+		return KindUnsupported, false, err
+	}
+
+	kind, ok := s.Results[image]
+	if !ok {
+		return KindUnsupported, false, nil
+	}
+
+	return kind, true, nil
+}
+
+// ResolveImageIndex implements ImageIndexResolver.
+func (s *StubRegistryResolver) ResolveImageIndex(image string) ([]ImageVariant, bool, error) {
+	if err, ok := s.IndexErrors[image]; ok {
+		return nil, false, err
+	}
+
+	variants, ok := s.IndexResults[image]
+	if !ok {
+		return nil, false, nil
+	}
+
+	return variants, true, nil
+}
+
+// ResolveDigest implements DigestResolver. Like HTTPRegistryResolver, it returns
+// ok=false when image is already digest-pinned, so callers can't accidentally exercise
+// digest-rewrite behavior against an already-pinned reference just because it happens to
+// appear in DigestResults.
+func (s *StubRegistryResolver) ResolveDigest(image string) (string, bool, error) {
+	if err, ok := s.DigestErrors[image]; ok {
+		return "", false, err
+	}
+
+	ref, err := ParseReference(image)
+	if err == nil && ref.Digest != "" {
+		return "", false, nil
+	}
+
+	digest, ok := s.DigestResults[image]
+	if !ok {
+		return "", false, nil
+	}
+
+	return digest, true, nil
+}
+
+// ResolvePorts implements PortResolver.
+func (s *StubRegistryResolver) ResolvePorts(image string) ([]ExposedPort, bool, error) {
+	if err, ok := s.PortErrors[image]; ok {
+		return nil, false, err
+	}
+
+	ports, ok := s.PortResults[image]
+	if !ok {
+		return nil, false, nil
+	}
+
+	return ports, true, nil
+}
+
+// imageConfig is the subset of an OCI/Docker image config blob that classifyFromConfig
+// inspects.
+type imageConfig struct {
+	Config imageConfigDetails `json:"config"`
+}
+
+// imageConfigDetails is the "config" object nested inside an image config blob.
+type imageConfigDetails struct {
+	Labels       map[string]string   `json:"Labels"`
+	ExposedPorts map[string]struct{} `json:"ExposedPorts"`
+}
+
+// portBackingServiceTable maps well-known container ports to the backing service that
+// conventionally listens on them, for images whose name alone doesn't give it away.
+var portBackingServiceTable = map[string]ResourceKind{
+	"6379/tcp":  KindRedisCache,
+	"5432/tcp":  KindSQLDB,
+	"3306/tcp":  KindSQLDB,
+	"27017/tcp": KindMongoDB,
+	"5672/tcp":  KindRabbitMQ,
+}
+
+// backingServiceLabelKeys lists the image config labels classifyFromConfig inspects, in
+// priority order: the standard OCI annotations, then the component-naming conventions
+// used by Bitnami images and Aspire's own hardened-container labels. This lets hardened
+// corporate images that don't share a well-known name (e.g. "internal.registry/platform/
+// keyvalue-store:2024.09") still be recognized as the backing service they actually run,
+// as long as the image carries one of these labels.
+var backingServiceLabelKeys = []string{
+	"org.opencontainers.image.title",
+	"org.opencontainers.image.source",
+	"io.aspire.component",
+	"io.bitnami.app-name",
+}
+
+// classifyFromConfig inspects an image's config blob for labels or exposed ports that
+// identify a well-known backing service, independent of the image's own name. It checks,
+// in order: the labels in backingServiceLabelKeys (matched the same way detectBackingService
+// matches an image name), then the set of exposed ports against portBackingServiceTable.
+func classifyFromConfig(cfg imageConfig) (ResourceKind, bool) {
+	for _, label := range backingServiceLabelKeys {
+		if value := cfg.Config.Labels[label]; value != "" {
+			if kind := detectBackingService(value); kind != KindUnsupported {
+				return kind, true
+			}
+		}
+	}
+
+	for port := range cfg.Config.ExposedPorts {
+		if kind, ok := portBackingServiceTable[port]; ok {
+			return kind, true
+		}
+	}
+
+	return KindUnsupported, false
+}
+
+// HTTPRegistryResolver resolves backing services by querying an OCI Distribution v2
+// registry for an image's manifest and config blob. It supports the common bearer-token
+// auth flow (a 401 response carrying "WWW-Authenticate: Bearer realm=...") used by
+// Docker Hub and most other registries, and caches resolved classifications on disk,
+// keyed by config digest, so repeated translations of the same manifest don't re-hit the
+// registry.
+type HTTPRegistryResolver struct {
+	// Client is the HTTP client used for registry requests. Defaults to http.DefaultClient.
+	Client *http.Client
+
+	// CacheDir, when non-empty, is a directory used to cache resolved classifications
+	// keyed by the image's config digest. The directory is created on first write if it
+	// does not already exist.
+	CacheDir string
+}
+
+// manifestResponse is the subset of a v2 manifest or manifest list/image index (Docker or
+// OCI) that ResolveBackingService and ResolveImageIndex need. A single-platform manifest
+// populates Config; a multi-arch index populates Manifests instead.
+type manifestResponse struct {
+	Config struct {
+		Digest string `json:"digest"`
+	} `json:"config"`
+
+	Manifests []indexManifestEntry `json:"manifests"`
+}
+
+// indexManifestEntry is one platform-specific entry in an image index / manifest list.
+type indexManifestEntry struct {
+	Digest   string `json:"digest"`
+	Size     int64  `json:"size"`
+	Platform struct {
+		Architecture string `json:"architecture"`
+		OS           string `json:"os"`
+	} `json:"platform"`
+}
+
+const (
+	acceptOCIManifest        = "application/vnd.oci.image.manifest.v1+json"
+	acceptDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	acceptOCIIndex           = "application/vnd.oci.image.index.v1+json"
+	acceptDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+)
+
+// ResolveBackingService implements RegistryResolver.
+func (r *HTTPRegistryResolver) ResolveBackingService(image string) (ResourceKind, bool, error) {
+	registry, repoPath, tagOrDigest, err := registryCoordinates(image)
+	if err != nil {
+		return KindUnsupported, false, err
+	}
+
+	manifest, _, err := r.fetchManifest(registry, repoPath, tagOrDigest)
+	if err != nil {
+		return KindUnsupported, false, err
+	}
+
+	if manifest.Config.Digest == "" {
+		// An image index has no config blob of its own; backing-service classification
+		// only applies to a single-platform manifest.
+		return KindUnsupported, false, nil
+	}
+
+	if kind, cached := r.readCache(manifest.Config.Digest); cached {
+		return kind, kind != KindUnsupported, nil
+	}
+
+	cfg, err := r.fetchConfig(registry, repoPath, manifest.Config.Digest)
+	if err != nil {
+		return KindUnsupported, false, err
+	}
+
+	kind, ok := classifyFromConfig(cfg)
+	r.writeCache(manifest.Config.Digest, kind)
+
+	return kind, ok, nil
+}
+
+// ResolveImageIndex implements ImageIndexResolver. It returns ok=false when image
+// resolves to a plain manifest rather than a multi-arch image index / manifest list.
+func (r *HTTPRegistryResolver) ResolveImageIndex(image string) ([]ImageVariant, bool, error) {
+	registry, repoPath, tagOrDigest, err := registryCoordinates(image)
+	if err != nil {
+		return nil, false, err
+	}
+
+	manifest, _, err := r.fetchManifest(registry, repoPath, tagOrDigest)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(manifest.Manifests) == 0 {
+		return nil, false, nil
+	}
+
+	variants := make([]ImageVariant, 0, len(manifest.Manifests))
+	for _, entry := range manifest.Manifests {
+		variants = append(variants, ImageVariant{
+			Platform: entry.Platform.OS + "/" + entry.Platform.Architecture,
+			Digest:   entry.Digest,
+			Size:     entry.Size,
+		})
+	}
+
+	return variants, true, nil
+}
+
+// ResolveDigest implements DigestResolver. It returns ok=false when image is already
+// pinned to a digest, since there is then nothing to resolve or rewrite.
+func (r *HTTPRegistryResolver) ResolveDigest(image string) (string, bool, error) {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return "", false, fmt.Errorf("parsing image reference %q: %w", image, err)
+	}
+
+	if ref.Digest != "" {
+		return "", false, nil
+	}
+
+	registry, repoPath, tagOrDigest, err := registryCoordinates(image)
+	if err != nil {
+		return "", false, err
+	}
+
+	_, digest, err := r.fetchManifest(registry, repoPath, tagOrDigest)
+	if err != nil {
+		return "", false, err
+	}
+
+	if digest == "" {
+		return "", false, nil
+	}
+
+	return digest, true, nil
+}
+
+// ResolvePorts implements PortResolver. It returns ok=false when image resolves to an
+// image index (no config blob of its own) or when its config declares no exposed ports.
+func (r *HTTPRegistryResolver) ResolvePorts(image string) ([]ExposedPort, bool, error) {
+	registry, repoPath, tagOrDigest, err := registryCoordinates(image)
+	if err != nil {
+		return nil, false, err
+	}
+
+	manifest, _, err := r.fetchManifest(registry, repoPath, tagOrDigest)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if manifest.Config.Digest == "" {
+		return nil, false, nil
+	}
+
+	cfg, err := r.fetchConfig(registry, repoPath, manifest.Config.Digest)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var ports []ExposedPort
+	for portProto := range cfg.Config.ExposedPorts {
+		portStr, protocol, _ := strings.Cut(portProto, "/")
+
+		port, convErr := strconv.Atoi(portStr)
+		if convErr != nil {
+			continue
+		}
+
+		if protocol == "" {
+			protocol = "tcp"
+		}
+
+		ports = append(ports, ExposedPort{Port: port, Protocol: strings.ToUpper(protocol)})
+	}
+
+	if len(ports) == 0 {
+		return nil, false, nil
+	}
+
+	sort.Slice(ports, func(i, j int) bool {
+		if ports[i].Port != ports[j].Port {
+			return ports[i].Port < ports[j].Port
+		}
+		return ports[i].Protocol < ports[j].Protocol
+	})
+
+	return ports, true, nil
+}
+
+// registryCoordinates derives the registry host, repository path, and tag-or-digest
+// reference that an OCI Distribution v2 manifest request needs from an image reference,
+// applying Docker Hub's implicit "registry-1.docker.io" host and "library/" namespace.
+func registryCoordinates(image string) (registry, repoPath, tagOrDigest string, err error) {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return "", "", "", fmt.Errorf("parsing image reference %q: %w", image, err)
+	}
+
+	registry = ref.Domain
+	if registry == "" {
+		registry = "registry-1.docker.io"
+	}
+
+	repoPath = ref.Path
+	if registry == "registry-1.docker.io" && !strings.Contains(repoPath, "/") {
+		repoPath = "library/" + repoPath
+	}
+
+	tagOrDigest = ref.Digest
+	if tagOrDigest == "" {
+		tagOrDigest = ref.Tag
+	}
+	if tagOrDigest == "" {
+		tagOrDigest = "latest"
+	}
+
+	return registry, repoPath, tagOrDigest, nil
+}
+
+func (r *HTTPRegistryResolver) httpClient() *http.Client {
+	if r.Client != nil {
+		return r.Client
+	}
+
+	return http.DefaultClient
+}
+
+// doAuthenticated performs req, retrying once with a bearer token if the registry
+// challenges the initial request with a 401 and a WWW-Authenticate: Bearer header.
+func (r *HTTPRegistryResolver) doAuthenticated(req *http.Request) (*http.Response, error) {
+	resp, err := r.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		return resp, nil
+	}
+
+	challenge := resp.Header.Get("WWW-Authenticate")
+	resp.Body.Close()
+
+	token, err := r.fetchBearerToken(challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	return r.httpClient().Do(req)
+}
+
+// fetchBearerToken requests a token from the realm named in a WWW-Authenticate: Bearer
+// challenge header, e.g.:
+//
+//	Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/redis:pull"
+func (r *HTTPRegistryResolver) fetchBearerToken(challenge string) (string, error) {
+	if !strings.HasPrefix(challenge, "Bearer ") {
+		return "", fmt.Errorf("unsupported auth challenge: %s", challenge)
+	}
+
+	params := parseAuthParams(strings.TrimPrefix(challenge, "Bearer "))
+
+	realm, ok := params["realm"]
+	if !ok {
+		return "", fmt.Errorf("auth challenge missing realm: %s", challenge)
+	}
+
+	query := make([]string, 0, len(params))
+	for key, value := range params {
+		if key == "realm" {
+			continue
+		}
+
+		query = append(query, key+"="+value)
+	}
+
+	url := realm
+	if len(query) > 0 {
+		url += "?" + strings.Join(query, "&")
+	}
+
+	resp, err := r.httpClient().Get(url)
+	if err != nil {
+		return "", fmt.Errorf("requesting auth token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("auth token request returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("decoding auth token response: %w", err)
+	}
+
+	if body.Token != "" {
+		return body.Token, nil
+	}
+
+	return body.AccessToken, nil
+}
+
+// parseAuthParams parses the comma-separated key="value" pairs of a WWW-Authenticate
+// challenge's parameter list.
+func parseAuthParams(s string) map[string]string {
+	params := make(map[string]string)
+
+	for _, part := range strings.Split(s, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+
+		params[kv[0]] = strings.Trim(kv[1], `"`)
+	}
+
+	return params
+}
+
+// fetchManifest fetches the manifest for registry/repoPath:tagOrDigest, returning alongside
+// it the manifest's own content digest (from the registry's Docker-Content-Digest response
+// header, or tagOrDigest itself when the caller already addressed the manifest by digest).
+func (r *HTTPRegistryResolver) fetchManifest(registry, repoPath, tagOrDigest string) (manifestResponse, string, error) {
+	url := fmt.Sprintf("https://%s/v2/%s/manifests/%s", registry, repoPath, tagOrDigest)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return manifestResponse{}, "", err
+	}
+
+	req.Header.Set("Accept", strings.Join([]string{acceptOCIManifest, acceptDockerManifest, acceptOCIIndex, acceptDockerManifestList}, ", "))
+
+	resp, err := r.doAuthenticated(req)
+	if err != nil {
+		return manifestResponse{}, "", fmt.Errorf("fetching manifest for %s/%s:%s: %w", registry, repoPath, tagOrDigest, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return manifestResponse{}, "", fmt.Errorf("fetching manifest for %s/%s:%s: status %d", registry, repoPath, tagOrDigest, resp.StatusCode)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" && strings.HasPrefix(tagOrDigest, "sha256:") {
+		digest = tagOrDigest
+	}
+
+	var manifest manifestResponse
+	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
+		return manifestResponse{}, "", fmt.Errorf("decoding manifest for %s/%s:%s: %w", registry, repoPath, tagOrDigest, err)
+	}
+
+	if manifest.Config.Digest == "" && len(manifest.Manifests) == 0 {
+		return manifestResponse{}, "", fmt.Errorf("manifest for %s/%s:%s has no config digest or platform manifests", registry, repoPath, tagOrDigest)
+	}
+
+	return manifest, digest, nil
+}
+
+func (r *HTTPRegistryResolver) fetchConfig(registry, repoPath, digest string) (imageConfig, error) {
+	url := fmt.Sprintf("https://%s/v2/%s/blobs/%s", registry, repoPath, digest)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return imageConfig{}, err
+	}
+
+	resp, err := r.doAuthenticated(req)
+	if err != nil {
+		return imageConfig{}, fmt.Errorf("fetching config blob %s for %s/%s: %w", digest, registry, repoPath, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return imageConfig{}, fmt.Errorf("fetching config blob %s for %s/%s: status %d", digest, registry, repoPath, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return imageConfig{}, fmt.Errorf("reading config blob %s for %s/%s: %w", digest, registry, repoPath, err)
+	}
+
+	var cfg imageConfig
+	if err := json.Unmarshal(body, &cfg); err != nil {
+		return imageConfig{}, fmt.Errorf("decoding config blob %s for %s/%s: %w", digest, registry, repoPath, err)
+	}
+
+	return cfg, nil
+}
+
+// cachePath returns the on-disk cache file path for a given config digest, sanitizing it
+// into a safe filename (digests are of the form "sha256:<hex>").
+func (r *HTTPRegistryResolver) cachePath(digest string) string {
+	sum := sha256.Sum256([]byte(digest))
+
+	return filepath.Join(r.CacheDir, hex.EncodeToString(sum[:])+".json")
+}
+
+// readCache returns the cached classification for digest, if CacheDir is set and a cache
+// entry exists.
+func (r *HTTPRegistryResolver) readCache(digest string) (ResourceKind, bool) {
+	if r.CacheDir == "" {
+		return "", false
+	}
+
+	data, err := os.ReadFile(r.cachePath(digest))
+	if err != nil {
+		return "", false
+	}
+
+	var entry struct {
+		Kind ResourceKind `json:"kind"`
+	}
+
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return "", false
+	}
+
+	return entry.Kind, true
+}
+
+// writeCache persists the classification for digest to CacheDir, if set. Failures are
+// ignored: the cache is a best-effort optimization, not required for correctness.
+func (r *HTTPRegistryResolver) writeCache(digest string, kind ResourceKind) {
+	if r.CacheDir == "" {
+		return
+	}
+
+	if err := os.MkdirAll(r.CacheDir, 0o755); err != nil {
+		return
+	}
+
+	data, err := json.Marshal(struct {
+		Kind ResourceKind `json:"kind"`
+	}{Kind: kind})
+	if err != nil {
+		return
+	}
+
+	_ = os.WriteFile(r.cachePath(digest), data, 0o644)
+}
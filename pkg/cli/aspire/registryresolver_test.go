@@ -0,0 +1,219 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestClassifyImage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil resolver falls back to name matching", func(t *testing.T) {
+		t.Parallel()
+
+		var warnings []string
+		kind := classifyImage("cache", "redis:7", nil, func(msg string) { warnings = append(warnings, msg) })
+
+		if kind != KindRedisCache {
+			t.Errorf("classifyImage() = %q, want %q", kind, KindRedisCache)
+		}
+
+		if len(warnings) != 0 {
+			t.Errorf("expected no warnings with a nil resolver, got %v", warnings)
+		}
+	})
+
+	t.Run("resolver match takes precedence over name matching", func(t *testing.T) {
+		t.Parallel()
+
+		resolver := &StubRegistryResolver{
+			Results: map[string]ResourceKind{"myapp/custom-cache:latest": KindRedisCache},
+		}
+
+		var warnings []string
+		kind := classifyImage("cache", "myapp/custom-cache:latest", resolver, func(msg string) { warnings = append(warnings, msg) })
+
+		if kind != KindRedisCache {
+			t.Errorf("classifyImage() = %q, want %q", kind, KindRedisCache)
+		}
+
+		if len(warnings) != 1 {
+			t.Errorf("expected exactly one warning recording the resolver outcome, got %v", warnings)
+		}
+	})
+
+	t.Run("resolver miss falls back to name matching", func(t *testing.T) {
+		t.Parallel()
+
+		resolver := &StubRegistryResolver{Results: map[string]ResourceKind{}}
+
+		var warnings []string
+		kind := classifyImage("cache", "redis:7", resolver, func(msg string) { warnings = append(warnings, msg) })
+
+		if kind != KindRedisCache {
+			t.Errorf("classifyImage() = %q, want %q", kind, KindRedisCache)
+		}
+
+		if len(warnings) != 1 {
+			t.Errorf("expected exactly one warning recording the resolver miss, got %v", warnings)
+		}
+	})
+
+	t.Run("resolver error falls back to name matching", func(t *testing.T) {
+		t.Parallel()
+
+		resolver := &StubRegistryResolver{
+			Errors: map[string]error{"redis:7": errors.New("registry unreachable")},
+		}
+
+		var warnings []string
+		kind := classifyImage("cache", "redis:7", resolver, func(msg string) { warnings = append(warnings, msg) })
+
+		if kind != KindRedisCache {
+			t.Errorf("classifyImage() = %q, want %q", kind, KindRedisCache)
+		}
+
+		if len(warnings) != 1 {
+			t.Errorf("expected exactly one warning recording the resolver error, got %v", warnings)
+		}
+	})
+
+	t.Run("addWarning may be nil", func(t *testing.T) {
+		t.Parallel()
+
+		resolver := &StubRegistryResolver{Results: map[string]ResourceKind{"redis:7": KindRedisCache}}
+
+		kind := classifyImage("cache", "redis:7", resolver, nil)
+		if kind != KindRedisCache {
+			t.Errorf("classifyImage() = %q, want %q", kind, KindRedisCache)
+		}
+	})
+}
+
+func TestClassifyFromConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		cfg      imageConfig
+		expected ResourceKind
+		expectOK bool
+	}{
+		{
+			name:     "title label identifies redis",
+			cfg:      imageConfig{Config: imageConfigDetails{Labels: map[string]string{"org.opencontainers.image.title": "redis"}}},
+			expected: KindRedisCache,
+			expectOK: true,
+		},
+		{
+			name:     "source label identifies postgres",
+			cfg:      imageConfig{Config: imageConfigDetails{Labels: map[string]string{"org.opencontainers.image.source": "https://github.com/docker-library/postgres"}}},
+			expected: KindSQLDB,
+			expectOK: true,
+		},
+		{
+			name:     "exposed port identifies mongo",
+			cfg:      imageConfig{Config: imageConfigDetails{ExposedPorts: map[string]struct{}{"27017/tcp": {}}}},
+			expected: KindMongoDB,
+			expectOK: true,
+		},
+		{
+			name:     "aspire component label identifies redis on a hardened image name",
+			cfg:      imageConfig{Config: imageConfigDetails{Labels: map[string]string{"io.aspire.component": "redis"}}},
+			expected: KindRedisCache,
+			expectOK: true,
+		},
+		{
+			name:     "bitnami app-name label identifies rabbitmq on a hardened image name",
+			cfg:      imageConfig{Config: imageConfigDetails{Labels: map[string]string{"io.bitnami.app-name": "rabbitmq"}}},
+			expected: KindRabbitMQ,
+			expectOK: true,
+		},
+		{
+			name:     "no identifying information",
+			cfg:      imageConfig{},
+			expected: KindUnsupported,
+			expectOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			kind, ok := classifyFromConfig(tt.cfg)
+			if kind != tt.expected || ok != tt.expectOK {
+				t.Errorf("classifyFromConfig() = (%q, %v), want (%q, %v)", kind, ok, tt.expected, tt.expectOK)
+			}
+		})
+	}
+}
+
+func TestHTTPRegistryResolver_Cache(t *testing.T) {
+	t.Parallel()
+
+	resolver := &HTTPRegistryResolver{CacheDir: t.TempDir()}
+
+	resolver.writeCache("sha256:abcd", KindRedisCache)
+
+	kind, ok := resolver.readCache("sha256:abcd")
+	if !ok {
+		t.Fatal("expected a cache hit after writeCache")
+	}
+
+	if kind != KindRedisCache {
+		t.Errorf("readCache() = %q, want %q", kind, KindRedisCache)
+	}
+
+	if _, ok := resolver.readCache("sha256:missing"); ok {
+		t.Error("expected a cache miss for a digest that was never written")
+	}
+}
+
+func TestHTTPRegistryResolver_CacheDisabled(t *testing.T) {
+	t.Parallel()
+
+	resolver := &HTTPRegistryResolver{}
+
+	resolver.writeCache("sha256:abcd", KindRedisCache)
+
+	if _, ok := resolver.readCache("sha256:abcd"); ok {
+		t.Error("expected no caching when CacheDir is empty")
+	}
+}
+
+func TestParseAuthParams(t *testing.T) {
+	t.Parallel()
+
+	challenge := `realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/redis:pull"`
+
+	params := parseAuthParams(challenge)
+
+	expected := map[string]string{
+		"realm":   "https://auth.docker.io/token",
+		"service": "registry.docker.io",
+		"scope":   "repository:library/redis:pull",
+	}
+
+	for key, want := range expected {
+		if got := params[key]; got != want {
+			t.Errorf("parseAuthParams()[%q] = %q, want %q", key, got, want)
+		}
+	}
+}
@@ -17,18 +17,69 @@ limitations under the License.
 package aspire
 
 import (
+	"encoding/base64"
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 )
 
-// AspireExpression represents a parsed reference extracted from an expression.
+// exprKind discriminates the node types produced by parseExprContent.
+type exprKind int
+
+const (
+	// exprKindRef is a plain "resource.path" reference.
+	exprKindRef exprKind = iota
+
+	// exprKindLiteral is a quoted string literal, only produced as a function call argument.
+	exprKindLiteral
+
+	// exprKindDefault wraps another node with a fallback, from "ref:-fallback" or
+	// "ref:?message" syntax.
+	exprKindDefault
+
+	// exprKindEnv is an "env:VAR" environment lookup against translationContext.env.
+	exprKindEnv
+
+	// exprKindCall is a "fn(arg1, arg2, ...)" builtin function call.
+	exprKindCall
+)
+
+// AspireExpression is a parsed expression AST node extracted from a "{...}" reference.
+// Kind determines which other fields are populated.
 type AspireExpression struct {
-	// ResourceName is the referenced resource name.
+	// Kind selects which of the fields below apply.
+	Kind exprKind
+
+	// ResourceName is the referenced resource name (exprKindRef).
 	ResourceName string
 
-	// PropertyPath contains path segments after the resource name.
+	// PropertyPath contains path segments after the resource name (exprKindRef).
 	PropertyPath []string
 
+	// Literal is a quoted string literal's value (exprKindLiteral).
+	Literal string
+
+	// Inner is the wrapped expression (exprKindDefault).
+	Inner *AspireExpression
+
+	// Fallback is the literal text substituted when Inner can't be resolved, or the
+	// message reported when Required is true (exprKindDefault).
+	Fallback string
+
+	// Required is true for "ref:?message" syntax: failure to resolve Inner is a
+	// translate-time error instead of a silent fallback (exprKindDefault).
+	Required bool
+
+	// EnvVar is the environment variable name to look up (exprKindEnv).
+	EnvVar string
+
+	// FuncName is the builtin function being called (exprKindCall).
+	FuncName string
+
+	// Args are the call's parsed arguments (exprKindCall).
+	Args []*AspireExpression
+
 	// RawText is the original {...} text for error reporting.
 	RawText string
 }
@@ -77,8 +128,8 @@ func parseExpressions(input string) *compositeValue {
 		rawText := remaining[openIdx : closeIdx+1]
 		exprContent := remaining[openIdx+1 : closeIdx]
 
-		// Parse the expression into resource name and property path.
-		expr := parseExpressionContent(exprContent, rawText)
+		// Parse the expression into an AST node.
+		expr := parseExprContent(exprContent, rawText)
 		cv.parts = append(cv.parts, valuePart{expression: expr})
 
 		remaining = remaining[closeIdx+1:]
@@ -87,20 +138,134 @@ func parseExpressions(input string) *compositeValue {
 	return cv
 }
 
-// parseExpressionContent parses the content inside {...} into an AspireExpression.
-func parseExpressionContent(content, rawText string) *AspireExpression {
+// functionCallPattern matches a "fn(args)" expression body.
+var functionCallPattern = regexp.MustCompile(`^(\w+)\((.*)\)$`)
+
+// parseExprContent parses the content inside a single {...} expression into an
+// AspireExpression AST node. Forms are recognized in priority order: a top-level
+// default/required suffix ("ref:-fallback", "ref:?message"), a quoted string literal, a
+// function call ("fn(arg1, arg2)"), an environment lookup ("env:VAR"), and finally a
+// plain "resource.path" reference.
+func parseExprContent(content, rawText string) *AspireExpression {
+	content = strings.TrimSpace(content)
+
+	if idx, required, ok := splitTopLevelDefault(content); ok {
+		inner := parseExprContent(content[:idx], rawText)
+		fallback := strings.TrimSpace(content[idx+2:])
+
+		return &AspireExpression{
+			Kind:     exprKindDefault,
+			Inner:    inner,
+			Fallback: fallback,
+			Required: required,
+			RawText:  rawText,
+		}
+	}
+
+	if len(content) >= 2 && strings.HasPrefix(content, `"`) && strings.HasSuffix(content, `"`) {
+		return &AspireExpression{Kind: exprKindLiteral, Literal: content[1 : len(content)-1], RawText: rawText}
+	}
+
+	if m := functionCallPattern.FindStringSubmatch(content); m != nil {
+		funcName := m[1]
+		argStrs := splitTopLevelArgs(m[2])
+		args := make([]*AspireExpression, 0, len(argStrs))
+
+		for _, argStr := range argStrs {
+			args = append(args, parseExprContent(argStr, rawText))
+		}
+
+		return &AspireExpression{Kind: exprKindCall, FuncName: funcName, Args: args, RawText: rawText}
+	}
+
+	if rest, ok := strings.CutPrefix(content, "env:"); ok {
+		return &AspireExpression{Kind: exprKindEnv, EnvVar: rest, RawText: rawText}
+	}
+
 	parts := strings.Split(content, ".")
 	if len(parts) == 0 {
-		return &AspireExpression{RawText: rawText}
+		return &AspireExpression{Kind: exprKindRef, RawText: rawText}
 	}
 
 	return &AspireExpression{
+		Kind:         exprKindRef,
 		ResourceName: parts[0],
 		PropertyPath: parts[1:],
 		RawText:      rawText,
 	}
 }
 
+// splitTopLevelDefault finds the first ":-" (fallback) or ":?" (required) separator in
+// content that isn't nested inside a function call's parentheses. ok is false when no
+// such separator exists.
+func splitTopLevelDefault(content string) (idx int, required bool, ok bool) {
+	depth := 0
+	inQuotes := false
+
+	for i := 0; i < len(content); i++ {
+		switch content[i] {
+		case '"':
+			inQuotes = !inQuotes
+		case '(':
+			if !inQuotes {
+				depth++
+			}
+		case ')':
+			if !inQuotes {
+				depth--
+			}
+		case ':':
+			if depth == 0 && !inQuotes && i+1 < len(content) {
+				switch content[i+1] {
+				case '-':
+					return i, false, true
+				case '?':
+					return i, true, true
+				}
+			}
+		}
+	}
+
+	return 0, false, false
+}
+
+// splitTopLevelArgs splits a function call's argument list on commas, ignoring commas
+// nested inside another function call's parentheses or inside a quoted string literal.
+func splitTopLevelArgs(argsStr string) []string {
+	if strings.TrimSpace(argsStr) == "" {
+		return nil
+	}
+
+	var args []string
+	depth := 0
+	inQuotes := false
+	start := 0
+
+	for i := 0; i < len(argsStr); i++ {
+		switch argsStr[i] {
+		case '"':
+			inQuotes = !inQuotes
+		case '(':
+			if !inQuotes {
+				depth++
+			}
+		case ')':
+			if !inQuotes {
+				depth--
+			}
+		case ',':
+			if depth == 0 && !inQuotes {
+				args = append(args, strings.TrimSpace(argsStr[start:i]))
+				start = i + 1
+			}
+		}
+	}
+
+	args = append(args, strings.TrimSpace(argsStr[start:]))
+
+	return args
+}
+
 // hasExpressions returns true if the composite value contains any expression references.
 func (cv *compositeValue) hasExpressions() bool {
 	for _, part := range cv.parts {
@@ -124,6 +289,11 @@ func resolveExpressions(ctx *translationContext) {
 		connections := make(map[string]ConnectionSpec)
 
 		for envKey, envSpec := range resource.Container.Env {
+			if secretRef := resolveSecretParamRef(envSpec.Value, ctx); secretRef != nil {
+				resolvedEnv[envKey] = EnvVarSpec{SecretRef: secretRef}
+				continue
+			}
+
 			cv := parseExpressions(envSpec.Value)
 			if !cv.hasExpressions() {
 				resolvedEnv[envKey] = envSpec
@@ -158,7 +328,46 @@ func resolveExpressions(ctx *translationContext) {
 	}
 }
 
+// resolveSecretParamRef recognizes an env value that is exactly a single reference to a
+// secret parameter.v0 resource (e.g. "{dbPassword}", with no surrounding literal text,
+// property path, default, or function wrapper), and returns the secret store key it
+// should be wired to instead of a resolved literal value. Returns nil when the value
+// isn't such a reference, the target isn't a secret parameter, or config.secretBackend
+// routes secrets to a Bicep param.
+func resolveSecretParamRef(value string, ctx *translationContext) *EnvSecretRefSpec {
+	if ctx.config.secretBackend == "" || ctx.config.secretBackend == SecretBackendBicepParam {
+		return nil
+	}
+
+	cv := parseExpressions(value)
+	if len(cv.parts) != 1 || cv.parts[0].expression == nil {
+		return nil
+	}
+
+	expr := cv.parts[0].expression
+	if expr.Kind != exprKindRef || len(expr.PropertyPath) != 0 {
+		return nil
+	}
+
+	targetName := expr.ResourceName
+	if ctx.kindMap[targetName] != KindParameter {
+		return nil
+	}
+
+	targetResource, ok := ctx.manifest.Resources[targetName]
+	if !ok || !isSecretParameter(targetResource) {
+		return nil
+	}
+
+	return &EnvSecretRefSpec{
+		SecretStoreIdentifier: "secretstore",
+		Key:                   sanitize(targetName),
+	}
+}
+
 // resolveCompositeValue resolves a composite value into a resolved env var and connections.
+// IsBicepInterpolation is set when any leaf expression anywhere in the tree (including
+// inside defaults and function-call arguments) resolved to a Bicep reference.
 func resolveCompositeValue(cv *compositeValue, sourceResource string, ctx *translationContext) (EnvVarSpec, map[string]ConnectionSpec, error) {
 	connections := make(map[string]ConnectionSpec)
 	var resultParts []string
@@ -170,28 +379,16 @@ func resolveCompositeValue(cv *compositeValue, sourceResource string, ctx *trans
 			continue
 		}
 
-		expr := part.expression
-		targetName := expr.ResourceName
-
-		// Validate the referenced resource exists.
-		targetKind, exists := ctx.kindMap[targetName]
-		if !exists {
-			return EnvVarSpec{}, nil, &unknownResourceError{
-				sourceResource: sourceResource,
-				targetResource: targetName,
-			}
-		}
-
-		resolved, connSpec, err := resolveSingleExpression(expr, targetName, targetKind, ctx)
+		resolved, conns, err := resolveExprNode(part.expression, sourceResource, ctx)
 		if err != nil {
 			return EnvVarSpec{}, nil, err
 		}
 
 		resultParts = append(resultParts, resolved)
 
-		if connSpec != nil {
-			connections[targetName] = *connSpec
-			if connSpec.IsBicepReference {
+		for targetName, conn := range conns {
+			connections[targetName] = conn
+			if conn.IsBicepReference {
 				hasBicepInterpolation = true
 			}
 		}
@@ -201,8 +398,146 @@ func resolveCompositeValue(cv *compositeValue, sourceResource string, ctx *trans
 	return EnvVarSpec{Value: value, IsBicepInterpolation: hasBicepInterpolation}, connections, nil
 }
 
-// resolveSingleExpression resolves a single expression reference to a value string and optional connection.
-func resolveSingleExpression(expr *AspireExpression, targetName string, targetKind ResourceKind, ctx *translationContext) (string, *ConnectionSpec, error) {
+// resolveExprNode resolves a single parsed expression node to text plus any resource
+// connections it implies, dispatching on the node's kind.
+func resolveExprNode(expr *AspireExpression, sourceResource string, ctx *translationContext) (string, map[string]ConnectionSpec, error) {
+	switch expr.Kind {
+	case exprKindLiteral:
+		return expr.Literal, nil, nil
+	case exprKindEnv:
+		value, err := resolveEnvExpression(expr, ctx)
+		return value, nil, err
+	case exprKindDefault:
+		return resolveDefaultExpression(expr, sourceResource, ctx)
+	case exprKindCall:
+		return resolveCallExpression(expr, sourceResource, ctx)
+	default:
+		return resolveRefExpression(expr, sourceResource, ctx)
+	}
+}
+
+// resolveRefExpression resolves a plain "resource.path" reference.
+func resolveRefExpression(expr *AspireExpression, sourceResource string, ctx *translationContext) (string, map[string]ConnectionSpec, error) {
+	targetName := expr.ResourceName
+
+	targetKind, exists := ctx.kindMap[targetName]
+	if !exists {
+		return "", nil, &unknownResourceError{
+			sourceResource: sourceResource,
+			targetResource: targetName,
+		}
+	}
+
+	resolved, connSpec, err := resolveSingleExpression(expr, sourceResource, targetName, targetKind, ctx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var conns map[string]ConnectionSpec
+	if connSpec != nil {
+		conns = map[string]ConnectionSpec{targetName: *connSpec}
+	}
+
+	return resolved, conns, nil
+}
+
+// resolveEnvExpression resolves an "env:VAR" lookup against translationContext.env.
+func resolveEnvExpression(expr *AspireExpression, ctx *translationContext) (string, error) {
+	if value, ok := ctx.env[expr.EnvVar]; ok {
+		return value, nil
+	}
+
+	return "", fmt.Errorf("expression %s references environment variable %q, which is not set in the translation environment", expr.RawText, expr.EnvVar)
+}
+
+// resolveDefaultExpression resolves a "ref:-fallback" or "ref:?message" node: it tries
+// Inner first, then either substitutes Fallback or reports a required-expression error.
+func resolveDefaultExpression(expr *AspireExpression, sourceResource string, ctx *translationContext) (string, map[string]ConnectionSpec, error) {
+	resolved, conns, err := resolveExprNode(expr.Inner, sourceResource, ctx)
+	if err == nil {
+		return resolved, conns, nil
+	}
+
+	if expr.Required {
+		return "", nil, &requiredExpressionError{
+			expression: expr.RawText,
+			message:    expr.Fallback,
+			cause:      err,
+		}
+	}
+
+	return expr.Fallback, nil, nil
+}
+
+// resolveCallExpression resolves a "fn(arg1, arg2, ...)" builtin function call by
+// resolving each argument, then applying the function to the resolved text.
+func resolveCallExpression(expr *AspireExpression, sourceResource string, ctx *translationContext) (string, map[string]ConnectionSpec, error) {
+	args := make([]string, 0, len(expr.Args))
+	conns := make(map[string]ConnectionSpec)
+
+	for _, argExpr := range expr.Args {
+		resolved, argConns, err := resolveExprNode(argExpr, sourceResource, ctx)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, resolved)
+
+		for targetName, conn := range argConns {
+			conns[targetName] = conn
+		}
+	}
+
+	resolved, err := applyExprFunc(expr.FuncName, args, expr.RawText)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return resolved, conns, nil
+}
+
+// applyExprFunc applies a builtin expression function to its already-resolved argument
+// strings.
+func applyExprFunc(funcName string, args []string, rawText string) (string, error) {
+	switch funcName {
+	case "base64":
+		if len(args) != 1 {
+			return "", fmt.Errorf("expression %s: base64() takes exactly 1 argument, got %d", rawText, len(args))
+		}
+
+		return base64.StdEncoding.EncodeToString([]byte(args[0])), nil
+	case "toLower":
+		if len(args) != 1 {
+			return "", fmt.Errorf("expression %s: toLower() takes exactly 1 argument, got %d", rawText, len(args))
+		}
+
+		return strings.ToLower(args[0]), nil
+	case "toUpper":
+		if len(args) != 1 {
+			return "", fmt.Errorf("expression %s: toUpper() takes exactly 1 argument, got %d", rawText, len(args))
+		}
+
+		return strings.ToUpper(args[0]), nil
+	case "trimPrefix":
+		if len(args) != 2 {
+			return "", fmt.Errorf("expression %s: trimPrefix() takes exactly 2 arguments, got %d", rawText, len(args))
+		}
+
+		return strings.TrimPrefix(args[0], args[1]), nil
+	case "join":
+		if len(args) < 2 {
+			return "", fmt.Errorf("expression %s: join() takes a separator and at least 1 value", rawText)
+		}
+
+		return strings.Join(args[1:], args[0]), nil
+	default:
+		return "", fmt.Errorf("expression %s: unknown function %q", rawText, funcName)
+	}
+}
+
+// resolveSingleExpression resolves a single plain reference's property path to a value
+// string and optional connection.
+func resolveSingleExpression(expr *AspireExpression, sourceResource, targetName string, targetKind ResourceKind, ctx *translationContext) (string, *ConnectionSpec, error) {
 	path := expr.PropertyPath
 
 	// Handle parameter references — resolve to Bicep parameter interpolation.
@@ -213,7 +548,14 @@ func resolveSingleExpression(expr *AspireExpression, targetName string, targetKi
 
 	// Handle connectionString references.
 	if len(path) == 1 && path[0] == "connectionString" {
-		return resolveConnectionString(targetName, targetKind, ctx)
+		return resolveConnectionString(sourceResource, targetName, targetKind, ctx)
+	}
+
+	// Handle build-output references: <name>.build.image resolves to the image an
+	// automatic build of <name> produced (ctx.builtImages), or failing that, the image
+	// supplied by a BuildOnly companion resource parented on <name> (buildCompanionImage).
+	if len(path) == 2 && path[0] == "build" && path[1] == "image" {
+		return resolveBuildImageReference(targetName, ctx)
 	}
 
 	// Handle bindings references: bindings.<name>.<property>
@@ -223,7 +565,13 @@ func resolveSingleExpression(expr *AspireExpression, targetName string, targetKi
 
 	// Handle value resource references.
 	if targetKind == KindValueResource {
-		return resolveValueReference(targetName, ctx)
+		return resolveValueReference(sourceResource, targetName, ctx)
+	}
+
+	// Fall back to the ResourceHandler registered for the target's type, if any (e.g. a
+	// custom kind contributed via RegisterResourceHandler).
+	if handler, ok := ctx.handlerMap[targetName]; ok {
+		return handler.Resolve(expr, ctx)
 	}
 
 	return "", nil, &unsupportedExpressionError{
@@ -232,8 +580,12 @@ func resolveSingleExpression(expr *AspireExpression, targetName string, targetKi
 	}
 }
 
-// resolveConnectionString resolves a {resource.connectionString} expression.
-func resolveConnectionString(targetName string, targetKind ResourceKind, ctx *translationContext) (string, *ConnectionSpec, error) {
+// resolveConnectionString resolves a {resource.connectionString} expression. Resolving a
+// container target recurses into the target's own ConnectionString field (see below), so
+// sourceResource/targetName are guarded against re-entering the same edge: detectCircularReferences
+// rejects cycles it can see ahead of time, and this guard turns any it misses into a
+// connectionStringCycleError instead of recursing without bound.
+func resolveConnectionString(sourceResource, targetName string, targetKind ResourceKind, ctx *translationContext) (string, *ConnectionSpec, error) {
 	if targetKind.IsPortableResource() {
 		// Portable resource — reference by .id.
 		targetID := ctx.nameMap[targetName]
@@ -246,8 +598,19 @@ func resolveConnectionString(targetName string, targetKind ResourceKind, ctx *tr
 	}
 
 	if targetKind == KindValueResource {
-		return resolveValueReference(targetName, ctx)
+		return resolveValueReference(sourceResource, targetName, ctx)
+	}
+
+	pairKey := sourceResource + "->" + targetName
+	if ctx.resolvingPairs[pairKey] {
+		return "", nil, &connectionStringCycleError{sourceResource: sourceResource, targetResource: targetName}
+	}
+
+	if ctx.resolvingPairs == nil {
+		ctx.resolvingPairs = make(map[string]bool)
 	}
+	ctx.resolvingPairs[pairKey] = true
+	defer delete(ctx.resolvingPairs, pairKey)
 
 	// Container with connectionString — resolve the target's connectionString expression recursively.
 	targetResource, ok := ctx.manifest.Resources[targetName]
@@ -369,6 +732,23 @@ func resolveContainerURL(targetName, bindingName string, ctx *translationContext
 	return url, conn, nil
 }
 
+// resolveBuildImageReference resolves a "<name>.build.image" expression to the image that
+// building targetName produced — either an automatic build recorded in ctx.builtImages, or
+// a BuildOnly companion resource parented on targetName (see buildCompanionImage). The
+// result is a plain string, not a Bicep reference, since the image was resolved at
+// translation time.
+func resolveBuildImageReference(targetName string, ctx *translationContext) (string, *ConnectionSpec, error) {
+	if image, ok := ctx.builtImages[targetName]; ok {
+		return image, &ConnectionSpec{Source: image, IsBicepReference: false}, nil
+	}
+
+	if image, ok := buildCompanionImage(ctx, targetName); ok {
+		return image, &ConnectionSpec{Source: image, IsBicepReference: false}, nil
+	}
+
+	return "", nil, fmt.Errorf("resource %q has no build output to reference via \"build.image\" (it was not built and has no BuildOnly companion)", targetName)
+}
+
 // buildBindingURL constructs a URL from a binding.
 func buildBindingURL(resourceName string, binding ManifestBinding) string {
 	scheme := binding.Scheme
@@ -384,8 +764,21 @@ func buildBindingURL(resourceName string, binding ManifestBinding) string {
 	return fmt.Sprintf("%s://%s:%d", scheme, resourceName, port)
 }
 
-// resolveValueReference resolves a reference to a value.v0 resource.
-func resolveValueReference(targetName string, ctx *translationContext) (string, *ConnectionSpec, error) {
+// resolveValueReference resolves a reference to a value.v0 resource. Like
+// resolveConnectionString, resolving a target whose own value is itself a connectionString
+// expression recurses, so the same (sourceResource, targetName) recursion guard applies.
+func resolveValueReference(sourceResource, targetName string, ctx *translationContext) (string, *ConnectionSpec, error) {
+	pairKey := sourceResource + "->" + targetName
+	if ctx.resolvingPairs[pairKey] {
+		return "", nil, &connectionStringCycleError{sourceResource: sourceResource, targetResource: targetName}
+	}
+
+	if ctx.resolvingPairs == nil {
+		ctx.resolvingPairs = make(map[string]bool)
+	}
+	ctx.resolvingPairs[pairKey] = true
+	defer delete(ctx.resolvingPairs, pairKey)
+
 	targetResource, ok := ctx.manifest.Resources[targetName]
 	if !ok {
 		return "", nil, &unknownResourceError{sourceResource: targetName, targetResource: targetName}
@@ -413,80 +806,242 @@ func resolveValueReference(targetName string, ctx *translationContext) (string,
 	return targetResource.Value, nil, nil
 }
 
-// detectCircularReferences checks for circular dependencies in the resource graph.
-// Only connectionString chains and value resource references are considered for cycles,
-// because binding URL/host/port references resolve to static values without recursion.
+// collectValidatedRefs returns the resource names a pre-translation validation pass
+// should confirm exist. It skips exprKindDefault subtrees entirely: a default (or
+// required) wrapper already turns an unresolved reference into a fallback value or a
+// dedicated error at resolve time, so it's not subject to this earlier existence check.
+func collectValidatedRefs(expr *AspireExpression) []string {
+	if expr == nil {
+		return nil
+	}
+
+	switch expr.Kind {
+	case exprKindDefault, exprKindEnv, exprKindLiteral:
+		return nil
+	case exprKindCall:
+		var targets []string
+		for _, arg := range expr.Args {
+			targets = append(targets, collectValidatedRefs(arg)...)
+		}
+
+		return targets
+	default:
+		return []string{expr.ResourceName}
+	}
+}
+
+// depEdge is one dependency edge discovered by collectDeps: the resource that owns the
+// expression depends on resource "to" because of the expression whose raw "{...}" text is
+// "via".
+type depEdge struct {
+	to  string
+	via string
+}
+
+// collectDeps walks an expression node for detectCircularReferences, recursing through
+// default wrappers' Inner (but never their literal Fallback) and function-call
+// arguments. When onlyConnectionString is true, a plain reference only counts as a
+// dependency if its property path is exactly ["connectionString"] — this is how a
+// top-level "{resource.bindings.foo.url}" in an env var is excluded: resolving a binding
+// reference never recurses back into resolveCompositeValue, so it can't itself be part of
+// a resolution cycle. A connectionString field has no such restriction, since
+// resolveConnectionString/resolveValueReference recurse into whatever the target's own
+// ConnectionString contains, bindings references included — see resolveBindingReference,
+// which is only a leaf when reached this way too, but the dependency still has to be
+// tracked so a cycle elsewhere in the chain is found.
+func collectDeps(expr *AspireExpression, onlyConnectionString bool) []depEdge {
+	if expr == nil {
+		return nil
+	}
+
+	switch expr.Kind {
+	case exprKindDefault:
+		return collectDeps(expr.Inner, onlyConnectionString)
+	case exprKindCall:
+		var deps []depEdge
+		for _, arg := range expr.Args {
+			deps = append(deps, collectDeps(arg, onlyConnectionString)...)
+		}
+
+		return deps
+	case exprKindEnv, exprKindLiteral:
+		return nil
+	default:
+		if onlyConnectionString && !(len(expr.PropertyPath) == 1 && expr.PropertyPath[0] == "connectionString") {
+			return nil
+		}
+
+		return []depEdge{{to: expr.ResourceName, via: expr.RawText}}
+	}
+}
+
+// detectCircularReferences checks for circular dependencies in the resource graph, built
+// from each resource's ConnectionString field (every reference inside it, including ones
+// reached transitively through bindings.*) plus each resource's Env values (only references
+// to another resource's connectionString — a direct "{resource.bindings.foo.url}" in an env
+// var resolves to a static value and can't recurse). It reports every strongly-connected
+// component it finds via Tarjan's algorithm, rather than stopping at the first cycle.
 func detectCircularReferences(ctx *translationContext) error {
-	// Build a dependency graph from connectionString expressions only.
-	// Binding references (bindings.xxx.url/host/port) resolve to static values
-	// and don't create actual data dependency cycles.
-	deps := make(map[string]map[string]bool)
+	deps := make(map[string][]depEdge, len(ctx.manifest.Resources))
 
 	for name, resource := range ctx.manifest.Resources {
-		deps[name] = make(map[string]bool)
+		var edges []depEdge
 
-		// Only track connectionString-based dependencies for cycle detection.
 		if resource.ConnectionString != "" {
 			cv := parseExpressions(resource.ConnectionString)
 			for _, part := range cv.parts {
-				if part.expression != nil {
-					deps[name][part.expression.ResourceName] = true
-				}
+				edges = append(edges, collectDeps(part.expression, false)...)
 			}
 		}
 
-		// Track env vars that reference connectionStrings (not bindings).
 		for _, value := range resource.Env {
 			cv := parseExpressions(value)
 			for _, part := range cv.parts {
-				if part.expression == nil {
-					continue
-				}
+				edges = append(edges, collectDeps(part.expression, true)...)
+			}
+		}
 
-				// Only count connectionString references as potential cycles.
-				if len(part.expression.PropertyPath) == 1 && part.expression.PropertyPath[0] == "connectionString" {
-					deps[name][part.expression.ResourceName] = true
+		deps[name] = edges
+	}
+
+	sccs := tarjanSCCs(deps)
+	if len(sccs) == 0 {
+		return nil
+	}
+
+	cycles := make([]circularReferenceCycle, 0, len(sccs))
+	for _, scc := range sccs {
+		members := make(map[string]bool, len(scc))
+		for _, name := range scc {
+			members[name] = true
+		}
+
+		var edges []circularDepEdge
+		for _, from := range scc {
+			for _, edge := range deps[from] {
+				if members[edge.to] {
+					edges = append(edges, circularDepEdge{from: from, to: edge.to, via: edge.via})
 				}
 			}
 		}
+
+		sort.Slice(edges, func(i, j int) bool {
+			if edges[i].from != edges[j].from {
+				return edges[i].from < edges[j].from
+			}
+
+			return edges[i].to < edges[j].to
+		})
+
+		cycles = append(cycles, circularReferenceCycle{resources: scc, edges: edges})
 	}
 
-	// DFS cycle detection.
-	visited := make(map[string]int) // 0=unvisited, 1=in-progress, 2=done
-	var chain []string
+	sort.Slice(cycles, func(i, j int) bool {
+		return cycles[i].resources[0] < cycles[j].resources[0]
+	})
 
-	var visit func(name string) error
-	visit = func(name string) error {
-		if visited[name] == 2 {
-			return nil
+	return &circularReferenceError{cycles: cycles}
+}
+
+// tarjanSCCs finds every strongly-connected component of size greater than one, plus every
+// single-resource component with a self-loop, in the dependency graph deps. Each returned
+// component's resource names are sorted for deterministic output; traversal itself also
+// walks resource names and edges in sorted order so two calls on the same graph always
+// report components in the same order.
+func tarjanSCCs(deps map[string][]depEdge) [][]string {
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var (
+		index   int
+		indices = make(map[string]int)
+		lowlink = make(map[string]int)
+		onStack = make(map[string]bool)
+		stack   []string
+		sccs    [][]string
+	)
+
+	var strongconnect func(v string)
+	strongconnect = func(v string) {
+		indices[v] = index
+		lowlink[v] = index
+		index++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		edges := append([]depEdge(nil), deps[v]...)
+		sort.Slice(edges, func(i, j int) bool { return edges[i].to < edges[j].to })
+
+		visitedTarget := make(map[string]bool, len(edges))
+		for _, edge := range edges {
+			w := edge.to
+			if visitedTarget[w] {
+				continue
+			}
+
+			visitedTarget[w] = true
+
+			if _, known := deps[w]; !known {
+				// w isn't a tracked resource (e.g. it doesn't exist, or it's outside
+				// ctx.manifest.Resources) — there's nothing further to traverse through it.
+				continue
+			}
+
+			if _, seen := indices[w]; !seen {
+				strongconnect(w)
+				if lowlink[w] < lowlink[v] {
+					lowlink[v] = lowlink[w]
+				}
+			} else if onStack[w] {
+				if indices[w] < lowlink[v] {
+					lowlink[v] = indices[w]
+				}
+			}
 		}
 
-		if visited[name] == 1 {
-			// Found a cycle — build the chain.
-			cycle := append(chain, name)
-			return &circularReferenceError{chain: cycle}
+		if lowlink[v] != indices[v] {
+			return
 		}
 
-		visited[name] = 1
-		chain = append(chain, name)
+		var scc []string
+		for {
+			n := len(stack) - 1
+			w := stack[n]
+			stack = stack[:n]
+			onStack[w] = false
+			scc = append(scc, w)
 
-		for dep := range deps[name] {
-			if err := visit(dep); err != nil {
-				return err
+			if w == v {
+				break
 			}
 		}
 
-		chain = chain[:len(chain)-1]
-		visited[name] = 2
+		if len(scc) > 1 || hasSelfEdge(deps[scc[0]], scc[0]) {
+			sort.Strings(scc)
+			sccs = append(sccs, scc)
+		}
+	}
 
-		return nil
+	for _, name := range names {
+		if _, seen := indices[name]; !seen {
+			strongconnect(name)
+		}
 	}
 
-	for name := range deps {
-		if err := visit(name); err != nil {
-			return err
+	return sccs
+}
+
+// hasSelfEdge reports whether edges contains a dependency on name itself.
+func hasSelfEdge(edges []depEdge, name string) bool {
+	for _, edge := range edges {
+		if edge.to == name {
+			return true
 		}
 	}
 
-	return nil
+	return false
 }
@@ -16,7 +16,10 @@ limitations under the License.
 
 package aspire
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestParseExpressions(t *testing.T) {
 	t.Parallel()
@@ -174,3 +177,471 @@ func TestDetectCircularReferences(t *testing.T) {
 		}
 	})
 }
+
+func TestResolveSecretParamRef(t *testing.T) {
+	t.Parallel()
+
+	baseCtx := func(backend SecretBackend) *translationContext {
+		return &translationContext{
+			config: &translationConfig{secretBackend: backend},
+			kindMap: map[string]ResourceKind{
+				"dbPassword": KindParameter,
+				"cache":      KindRedisCache,
+			},
+			manifest: &AspireManifest{
+				Resources: map[string]ManifestResource{
+					"dbPassword": {
+						Type:   "parameter.v0",
+						Inputs: map[string]ManifestParamInput{"value": {Secret: true}},
+					},
+					"cache": {Type: "redis.server.v0"},
+				},
+			},
+		}
+	}
+
+	t.Run("secret param with non-default backend", func(t *testing.T) {
+		t.Parallel()
+
+		ref := resolveSecretParamRef("{dbPassword}", baseCtx(SecretBackendRadiusSecretStore))
+		if ref == nil {
+			t.Fatal("expected a secret ref")
+		}
+
+		if ref.Key != "dbPassword" || ref.SecretStoreIdentifier != "secretstore" {
+			t.Errorf("unexpected secret ref: %+v", ref)
+		}
+	})
+
+	t.Run("default backend leaves params unresolved here", func(t *testing.T) {
+		t.Parallel()
+
+		if ref := resolveSecretParamRef("{dbPassword}", baseCtx(SecretBackendBicepParam)); ref != nil {
+			t.Errorf("expected no secret ref for the default backend, got %+v", ref)
+		}
+	})
+
+	t.Run("non-parameter reference", func(t *testing.T) {
+		t.Parallel()
+
+		if ref := resolveSecretParamRef("{cache.connectionString}", baseCtx(SecretBackendRadiusSecretStore)); ref != nil {
+			t.Errorf("expected no secret ref for a non-parameter reference, got %+v", ref)
+		}
+	})
+
+	t.Run("value embedded in literal text is not a full reference", func(t *testing.T) {
+		t.Parallel()
+
+		if ref := resolveSecretParamRef("prefix-{dbPassword}", baseCtx(SecretBackendRadiusSecretStore)); ref != nil {
+			t.Errorf("expected no secret ref for a partial reference, got %+v", ref)
+		}
+	})
+}
+
+func TestParseExprContent_Extensions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default fallback", func(t *testing.T) {
+		t.Parallel()
+
+		expr := cv1(t, "{cache.connectionString:-redis://localhost:6379}")
+		if expr.Kind != exprKindDefault || expr.Required {
+			t.Fatalf("expected a non-required default node, got %+v", expr)
+		}
+
+		if expr.Fallback != "redis://localhost:6379" {
+			t.Errorf("expected fallback %q, got %q", "redis://localhost:6379", expr.Fallback)
+		}
+
+		if expr.Inner.Kind != exprKindRef || expr.Inner.ResourceName != "cache" {
+			t.Errorf("expected inner ref to 'cache', got %+v", expr.Inner)
+		}
+	})
+
+	t.Run("required", func(t *testing.T) {
+		t.Parallel()
+
+		expr := cv1(t, "{dbPassword:?a password must be supplied}")
+		if expr.Kind != exprKindDefault || !expr.Required {
+			t.Fatalf("expected a required default node, got %+v", expr)
+		}
+
+		if expr.Fallback != "a password must be supplied" {
+			t.Errorf("expected message %q, got %q", "a password must be supplied", expr.Fallback)
+		}
+	})
+
+	t.Run("env lookup", func(t *testing.T) {
+		t.Parallel()
+
+		expr := cv1(t, "{env:LOG_LEVEL}")
+		if expr.Kind != exprKindEnv || expr.EnvVar != "LOG_LEVEL" {
+			t.Errorf("expected an env lookup for LOG_LEVEL, got %+v", expr)
+		}
+	})
+
+	t.Run("env lookup with default", func(t *testing.T) {
+		t.Parallel()
+
+		expr := cv1(t, "{env:LOG_LEVEL:-info}")
+		if expr.Kind != exprKindDefault || expr.Fallback != "info" {
+			t.Fatalf("expected a default wrapping an env lookup, got %+v", expr)
+		}
+
+		if expr.Inner.Kind != exprKindEnv || expr.Inner.EnvVar != "LOG_LEVEL" {
+			t.Errorf("expected inner env lookup for LOG_LEVEL, got %+v", expr.Inner)
+		}
+	})
+
+	t.Run("function call", func(t *testing.T) {
+		t.Parallel()
+
+		expr := cv1(t, `{trimPrefix(cache.bindings.tcp.url, "redis://")}`)
+		if expr.Kind != exprKindCall || expr.FuncName != "trimPrefix" {
+			t.Fatalf("expected a trimPrefix call, got %+v", expr)
+		}
+
+		if len(expr.Args) != 2 {
+			t.Fatalf("expected 2 args, got %d", len(expr.Args))
+		}
+
+		if expr.Args[0].Kind != exprKindRef || expr.Args[0].ResourceName != "cache" {
+			t.Errorf("expected first arg to reference 'cache', got %+v", expr.Args[0])
+		}
+
+		if expr.Args[1].Kind != exprKindLiteral || expr.Args[1].Literal != "redis://" {
+			t.Errorf("expected second arg literal 'redis://', got %+v", expr.Args[1])
+		}
+	})
+}
+
+// cv1 parses input and returns the single expression node it produces.
+func cv1(t *testing.T, input string) *AspireExpression {
+	t.Helper()
+
+	cv := parseExpressions(input)
+	if len(cv.parts) != 1 || cv.parts[0].expression == nil {
+		t.Fatalf("expected a single expression part, got %+v", cv.parts)
+	}
+
+	return cv.parts[0].expression
+}
+
+func TestResolveCompositeValue_Extensions(t *testing.T) {
+	t.Parallel()
+
+	ctx := &translationContext{
+		config:  &translationConfig{},
+		kindMap: map[string]ResourceKind{"api": KindContainer},
+		manifest: &AspireManifest{
+			Resources: map[string]ManifestResource{
+				"api": {Type: "container.v0"},
+			},
+		},
+		env: map[string]string{"LOG_LEVEL": "debug"},
+	}
+
+	t.Run("default substitutes on unresolvable reference", func(t *testing.T) {
+		t.Parallel()
+
+		env, _, err := resolveCompositeValue(parseExpressions("{missing.connectionString:-sqlite://local.db}"), "api", ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if env.Value != "sqlite://local.db" {
+			t.Errorf("expected fallback value, got %q", env.Value)
+		}
+	})
+
+	t.Run("required reference surfaces an error", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := resolveCompositeValue(parseExpressions("{missing.connectionString:?missing is required}"), "api", ctx)
+		if err == nil {
+			t.Fatal("expected a required-expression error")
+		}
+	})
+
+	t.Run("env lookup resolves from translationContext.env", func(t *testing.T) {
+		t.Parallel()
+
+		env, _, err := resolveCompositeValue(parseExpressions("{env:LOG_LEVEL}"), "api", ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if env.Value != "debug" {
+			t.Errorf("expected 'debug', got %q", env.Value)
+		}
+	})
+
+	t.Run("unset env lookup falls back to default", func(t *testing.T) {
+		t.Parallel()
+
+		env, _, err := resolveCompositeValue(parseExpressions("{env:MISSING_VAR:-fallback}"), "api", ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if env.Value != "fallback" {
+			t.Errorf("expected 'fallback', got %q", env.Value)
+		}
+	})
+
+	t.Run("function call transforms the resolved value", func(t *testing.T) {
+		t.Parallel()
+
+		env, _, err := resolveCompositeValue(parseExpressions("{toUpper(env:LOG_LEVEL)}"), "api", ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if env.Value != "DEBUG" {
+			t.Errorf("expected 'DEBUG', got %q", env.Value)
+		}
+	})
+
+	t.Run("join concatenates multiple values with a separator", func(t *testing.T) {
+		t.Parallel()
+
+		env, _, err := resolveCompositeValue(parseExpressions(`{join(",", env:LOG_LEVEL, "debug")}`), "api", ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if env.Value != "debug,debug" {
+			t.Errorf("expected 'debug,debug', got %q", env.Value)
+		}
+	})
+}
+
+func TestDetectCircularReferences_ThroughDefault(t *testing.T) {
+	t.Parallel()
+
+	t.Run("cycle through a default's inner reference is still detected", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := &translationContext{
+			manifest: &AspireManifest{
+				Resources: map[string]ManifestResource{
+					"a": {Type: "value.v0", ConnectionString: "{b.connectionString:-fallback}"},
+					"b": {Type: "value.v0", ConnectionString: "{a.connectionString}"},
+				},
+			},
+		}
+
+		if err := detectCircularReferences(ctx); err == nil {
+			t.Error("expected circular reference error")
+		}
+	})
+
+	t.Run("a default's literal fallback is not traversed", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := &translationContext{
+			manifest: &AspireManifest{
+				Resources: map[string]ManifestResource{
+					// The fallback "a" is plain literal text substituted on failure; it must
+					// not be mistaken for a dependency on the resource named "a".
+					"a": {Type: "value.v0", ConnectionString: "{missing.connectionString:-a}"},
+				},
+			},
+		}
+
+		if err := detectCircularReferences(ctx); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestDetectCircularReferences_ThroughBindingInConnectionString(t *testing.T) {
+	t.Parallel()
+
+	// "a" depends on "b"'s connectionString, which itself embeds a binding reference back
+	// to "a". resolveBindingReference never recurses, but the cycle still exists in the
+	// dependency graph: resolving "a" needs "b"'s connectionString, which needs a value
+	// from "a".
+	ctx := &translationContext{
+		manifest: &AspireManifest{
+			Resources: map[string]ManifestResource{
+				"a": {Type: "value.v0", ConnectionString: "{b.connectionString}"},
+				"b": {Type: "container.v0", ConnectionString: "{a.bindings.http.url}"},
+			},
+		},
+	}
+
+	err := detectCircularReferences(ctx)
+	if err == nil {
+		t.Fatal("expected circular reference error")
+	}
+
+	cycleErr, ok := err.(*circularReferenceError)
+	if !ok {
+		t.Fatalf("expected *circularReferenceError, got %T", err)
+	}
+
+	if len(cycleErr.cycles) != 1 || len(cycleErr.cycles[0].resources) != 2 {
+		t.Fatalf("expected a single 2-resource cycle, got %+v", cycleErr.cycles)
+	}
+}
+
+func TestDetectCircularReferences_ReportsAllSCCsTogether(t *testing.T) {
+	t.Parallel()
+
+	// Two independent cycles — "a"/"b" and "x"/"y" — should both be reported by a single
+	// call, not just whichever one a DFS happens to reach first.
+	ctx := &translationContext{
+		manifest: &AspireManifest{
+			Resources: map[string]ManifestResource{
+				"a": {Type: "value.v0", ConnectionString: "{b.connectionString}"},
+				"b": {Type: "value.v0", ConnectionString: "{a.connectionString}"},
+				"x": {Type: "value.v0", ConnectionString: "{y.connectionString}"},
+				"y": {Type: "value.v0", ConnectionString: "{x.connectionString}"},
+			},
+		},
+	}
+
+	err := detectCircularReferences(ctx)
+	if err == nil {
+		t.Fatal("expected circular reference error")
+	}
+
+	cycleErr, ok := err.(*circularReferenceError)
+	if !ok {
+		t.Fatalf("expected *circularReferenceError, got %T", err)
+	}
+
+	if len(cycleErr.cycles) != 2 {
+		t.Fatalf("expected 2 cycles, got %d: %+v", len(cycleErr.cycles), cycleErr.cycles)
+	}
+}
+
+func TestDetectCircularReferences_ErrorIncludesCycleAndExpressionText(t *testing.T) {
+	t.Parallel()
+
+	ctx := &translationContext{
+		manifest: &AspireManifest{
+			Resources: map[string]ManifestResource{
+				"a": {Type: "value.v0", ConnectionString: "{b.connectionString}"},
+				"b": {Type: "value.v0", ConnectionString: "{a.connectionString}"},
+			},
+		},
+	}
+
+	err := detectCircularReferences(ctx)
+	if err == nil {
+		t.Fatal("expected circular reference error")
+	}
+
+	msg := err.Error()
+	for _, want := range []string{"a", "b", "{b.connectionString}", "{a.connectionString}"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("error message %q does not mention %q", msg, want)
+		}
+	}
+}
+
+func TestResolveConnectionString_RecursionGuard(t *testing.T) {
+	t.Parallel()
+
+	ctx := &translationContext{
+		manifest: &AspireManifest{
+			Resources: map[string]ManifestResource{
+				"a": {Type: "container.v0", ConnectionString: "{b.connectionString}"},
+				"b": {Type: "container.v0", ConnectionString: "{a.connectionString}"},
+			},
+		},
+		resolvingPairs: map[string]bool{"a->b": true},
+	}
+
+	_, _, err := resolveConnectionString("a", "b", KindContainer, ctx)
+	if err == nil {
+		t.Fatal("expected a recursion error")
+	}
+
+	if _, ok := err.(*connectionStringCycleError); !ok {
+		t.Errorf("expected *connectionStringCycleError, got %T", err)
+	}
+}
+
+func TestResolveValueReference_RecursionGuard(t *testing.T) {
+	t.Parallel()
+
+	ctx := &translationContext{
+		manifest: &AspireManifest{
+			Resources: map[string]ManifestResource{
+				"a": {Type: "value.v0", ConnectionString: "{b.connectionString}"},
+				"b": {Type: "value.v0", ConnectionString: "{a.connectionString}"},
+			},
+		},
+		resolvingPairs: map[string]bool{"a->b": true},
+	}
+
+	_, _, err := resolveValueReference("a", "b", ctx)
+	if err == nil {
+		t.Fatal("expected a recursion error")
+	}
+
+	if _, ok := err.(*connectionStringCycleError); !ok {
+		t.Errorf("expected *connectionStringCycleError, got %T", err)
+	}
+}
+
+func TestResolveBuildImageReference_FromBuiltImages(t *testing.T) {
+	t.Parallel()
+
+	ctx := &translationContext{
+		manifest:    &AspireManifest{Resources: map[string]ManifestResource{}},
+		builtImages: map[string]string{"frontend": "myregistry.io/frontend:latest"},
+	}
+
+	image, conn, err := resolveBuildImageReference("frontend", ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if image != "myregistry.io/frontend:latest" {
+		t.Errorf("expected built image, got %q", image)
+	}
+
+	if conn == nil || conn.IsBicepReference {
+		t.Errorf("expected a plain (non-Bicep-reference) connection, got %+v", conn)
+	}
+}
+
+func TestResolveBuildImageReference_FromBuildOnlyCompanion(t *testing.T) {
+	t.Parallel()
+
+	ctx := &translationContext{
+		manifest: &AspireManifest{
+			Resources: map[string]ManifestResource{
+				"frontend-build": {Type: "container.v0", BuildOnly: true, Parent: "frontend", Image: "myregistry.io/frontend:sha-abc123"},
+			},
+		},
+		builtImages: map[string]string{},
+	}
+
+	image, _, err := resolveBuildImageReference("frontend", ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if image != "myregistry.io/frontend:sha-abc123" {
+		t.Errorf("expected BuildOnly companion's image, got %q", image)
+	}
+}
+
+func TestResolveBuildImageReference_NoBuildOutput(t *testing.T) {
+	t.Parallel()
+
+	ctx := &translationContext{
+		manifest:    &AspireManifest{Resources: map[string]ManifestResource{}},
+		builtImages: map[string]string{},
+	}
+
+	_, _, err := resolveBuildImageReference("frontend", ctx)
+	if err == nil {
+		t.Fatal("expected an error when the target resource has no build output")
+	}
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResourceHandler lets external Go code teach the translator about an Aspire resource
+// type it doesn't know about natively (e.g. "azure.storage.blob.v0", "dapr.pubsub.v0"),
+// without patching classify's and mapResources' core dispatch. Register one with
+// RegisterResourceHandler; the built-in container/project/value/parameter handlers are
+// registered the same way, from this package's init().
+type ResourceHandler interface {
+	// Kind determines the ResourceKind a resource of this type maps to.
+	Kind(resource ManifestResource) ResourceKind
+
+	// Map converts the resource into a translated RadiusResource. It may read and write
+	// ctx (e.g. to append a Bicep parameter or a warning) the same way the built-in
+	// mapContainer/mapPortableResource paths do. A nil *RadiusResource with a nil error
+	// means the resource intentionally produces no standalone resource (as KindValueResource
+	// does today).
+	Map(name string, resource ManifestResource, ctx *translationContext) (*RadiusResource, error)
+
+	// Resolve resolves an expression ("{resource.path}") referencing a resource of this
+	// type. It is only consulted once resolveSingleExpression's built-in path shapes
+	// (connectionString, bindings.*) have been ruled out.
+	Resolve(expr *AspireExpression, ctx *translationContext) (string, *ConnectionSpec, error)
+}
+
+// resourceHandlers maps a resource "type" prefix (e.g. "container.v", "value.v0") to the
+// ResourceHandler registered for it.
+var resourceHandlers = map[string]ResourceHandler{}
+
+// RegisterResourceHandler registers h to handle resource types whose "type" field starts
+// with typePrefix (e.g. "container.v" matches "container.v0" and "container.v1", while
+// "value.v0" matches only that exact type). Re-registering a prefix replaces the
+// previous handler. Intended to be called from an init() function, mirroring how Aspire
+// manifest schema decoders and registry resolvers are registered elsewhere in this
+// package.
+func RegisterResourceHandler(typePrefix string, h ResourceHandler) {
+	resourceHandlers[typePrefix] = h
+}
+
+// handlerForType returns the registered ResourceHandler whose prefix most specifically
+// matches resourceType, or false if none matches.
+func handlerForType(resourceType string) (ResourceHandler, bool) {
+	var bestPrefix string
+	var best ResourceHandler
+	found := false
+
+	for prefix, h := range resourceHandlers {
+		if strings.HasPrefix(resourceType, prefix) && len(prefix) > len(bestPrefix) {
+			bestPrefix = prefix
+			best = h
+			found = true
+		}
+	}
+
+	return best, found
+}
+
+func init() {
+	RegisterResourceHandler("container.v", containerResourceHandler{})
+	RegisterResourceHandler("project.v", containerResourceHandler{})
+	RegisterResourceHandler("value.v0", valueResourceHandler{})
+	RegisterResourceHandler("parameter.v0", parameterResourceHandler{})
+
+	RegisterResourceHandler("azure.storage.blob.v0", azureManagedServiceHandler{
+		category:    "Azure Storage (Blob)",
+		placeholder: "<YOUR_STORAGE_ACCOUNT_RESOURCE_ID>",
+		properties:  map[string]string{"blobEndpoint": "blobEndpoint"},
+	})
+	RegisterResourceHandler("azure.servicebus.v0", azureManagedServiceHandler{
+		category:    "Azure Service Bus",
+		placeholder: "<YOUR_SERVICEBUS_NAMESPACE_RESOURCE_ID>",
+		properties:  map[string]string{"endpoint": "endpoint"},
+	})
+	RegisterResourceHandler("azure.keyvault.v0", azureManagedServiceHandler{
+		category:    "Azure Key Vault",
+		placeholder: "<YOUR_KEYVAULT_RESOURCE_ID>",
+		properties:  map[string]string{"vaultUri": "vaultUri"},
+	})
+	RegisterResourceHandler("azure.cosmosdb.v0", azureManagedServiceHandler{
+		category:    "Azure Cosmos DB",
+		placeholder: "<YOUR_COSMOSDB_ACCOUNT_RESOURCE_ID>",
+		properties:  map[string]string{"connectionString": "endpoint"},
+	})
+	RegisterResourceHandler("azure.appconfiguration.v0", azureManagedServiceHandler{
+		category:    "Azure App Configuration",
+		placeholder: "<YOUR_APPCONFIGURATION_RESOURCE_ID>",
+		properties:  map[string]string{"endpoint": "endpoint"},
+	})
+}
+
+// containerResourceHandler is the built-in handler for container.v0/v1 and project.v0/v1
+// resources; both map to KindContainer, with the project/build-mode nuances handled
+// inside mapContainer itself.
+type containerResourceHandler struct{}
+
+func (containerResourceHandler) Kind(ManifestResource) ResourceKind {
+	return KindContainer
+}
+
+func (containerResourceHandler) Map(name string, resource ManifestResource, ctx *translationContext) (*RadiusResource, error) {
+	bicepID := ctx.nameMap[name]
+
+	mapped, builtImage, warnings, err := mapContainer(name, resource, bicepID, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, warning := range warnings {
+		ctx.addWarning(fmt.Sprintf("Resource %q: %s", name, warning))
+	}
+
+	if mapped.Container != nil && mapped.Container.ImageParam != "" {
+		ctx.parameters = append(ctx.parameters, BicepParameter{
+			Name:        mapped.Container.ImageParam,
+			Type:        "string",
+			Description: fmt.Sprintf("Container image for project resource %q (build and push it, e.g. with `dotnet publish /t:PublishContainer`, then supply its tag)", name),
+		})
+	}
+
+	if builtImage != "" {
+		ctx.builtImages[name] = builtImage
+
+		if ctx.config.buildMode == BuildModeTektonPipeline {
+			ctx.tektonProjects = append(ctx.tektonProjects, tektonProject{
+				BicepIdentifier: bicepID,
+				Image:           builtImage,
+				Path:            resource.Path,
+			})
+		}
+	}
+
+	return mapped, nil
+}
+
+func (containerResourceHandler) Resolve(expr *AspireExpression, ctx *translationContext) (string, *ConnectionSpec, error) {
+	return "", nil, &unsupportedExpressionError{resourceName: expr.ResourceName, expression: expr.RawText}
+}
+
+// valueResourceHandler is the built-in handler for value.v0 resources, which are inlined
+// into consumers via resolveValueReference rather than becoming a standalone resource.
+type valueResourceHandler struct{}
+
+func (valueResourceHandler) Kind(ManifestResource) ResourceKind {
+	return KindValueResource
+}
+
+func (valueResourceHandler) Map(name string, resource ManifestResource, ctx *translationContext) (*RadiusResource, error) {
+	return nil, nil
+}
+
+func (valueResourceHandler) Resolve(expr *AspireExpression, ctx *translationContext) (string, *ConnectionSpec, error) {
+	// resolveSingleExpression's dedicated KindValueResource branch handles this resource
+	// kind before the handler dispatch is ever consulted, so sourceResource is unknown
+	// here; it's only needed to scope the recursion guard to a specific caller edge.
+	return resolveValueReference("", expr.ResourceName, ctx)
+}
+
+// parameterResourceHandler is the built-in handler for parameter.v0 resources, which
+// become Bicep parameters rather than standalone Radius resources.
+type parameterResourceHandler struct{}
+
+func (parameterResourceHandler) Kind(ManifestResource) ResourceKind {
+	return KindParameter
+}
+
+func (parameterResourceHandler) Map(name string, resource ManifestResource, ctx *translationContext) (*RadiusResource, error) {
+	mapParameter(name, resource, ctx)
+	return nil, nil
+}
+
+func (parameterResourceHandler) Resolve(expr *AspireExpression, ctx *translationContext) (string, *ConnectionSpec, error) {
+	return "${" + sanitize(expr.ResourceName) + "}", nil, nil
+}
+
+// azureManagedServiceHandler is the built-in handler for azure.*.v0 resources: managed
+// Azure services that have no dedicated Radius portable resource type. Each is mapped to
+// a manually-provisioned Applications.Core/extenders resource referencing the existing
+// Azure resource, which the user supplies after generation (see ExtenderSpec.Resource).
+type azureManagedServiceHandler struct {
+	// category is a short human-readable label for the Azure service, used in the
+	// synthesis warning and stashed on ExtenderSpec.Category.
+	category string
+
+	// placeholder is the `<YOUR_..._RESOURCE_ID>` text filled into ExtenderSpec.Resource.
+	placeholder string
+
+	// properties maps an Aspire expression property name (e.g. "blobEndpoint") to the
+	// name it's emitted under on the extender's `properties`, so Resolve can translate
+	// `{storage.blobEndpoint}` into a Bicep reference on the emitted resource instead of
+	// falling back to `<name>.id`.
+	properties map[string]string
+}
+
+func (h azureManagedServiceHandler) Kind(ManifestResource) ResourceKind {
+	return KindExtender
+}
+
+func (h azureManagedServiceHandler) Map(name string, resource ManifestResource, ctx *translationContext) (*RadiusResource, error) {
+	bicepID := ctx.nameMap[name]
+
+	ctx.addWarning(fmt.Sprintf("Resource %q: %s has no dedicated Radius portable resource type; synthesizing an extender referencing %s (replace it with the actual resource ID)", name, h.category, h.placeholder))
+
+	return &RadiusResource{
+		BicepIdentifier: bicepID,
+		RuntimeName:     name,
+		RadiusType:      string(KindExtender),
+		APIVersion:      apiVersion,
+		Kind:            KindExtender,
+		Extender: &ExtenderSpec{
+			Category: h.category,
+			Resource: h.placeholder,
+		},
+	}, nil
+}
+
+func (h azureManagedServiceHandler) Resolve(expr *AspireExpression, ctx *translationContext) (string, *ConnectionSpec, error) {
+	bicepID := ctx.nameMap[expr.ResourceName]
+
+	if len(expr.PropertyPath) == 1 {
+		if property, ok := h.properties[expr.PropertyPath[0]]; ok {
+			source := bicepID + ".properties." + property
+			conn := &ConnectionSpec{Source: source, IsBicepReference: true}
+
+			return "${" + source + "}", conn, nil
+		}
+	}
+
+	// Unrecognized property: fall back to referencing the extender by ID, mirroring how
+	// resolveConnectionString treats portable resources.
+	conn := &ConnectionSpec{Source: bicepID + ".id", IsBicepReference: true}
+
+	return "${" + bicepID + ".id}", conn, nil
+}
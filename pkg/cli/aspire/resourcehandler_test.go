@@ -0,0 +1,238 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import "testing"
+
+// stubResourceHandler is a test double for ResourceHandler.
+type stubResourceHandler struct {
+	kind ResourceKind
+}
+
+func (h stubResourceHandler) Kind(ManifestResource) ResourceKind {
+	return h.kind
+}
+
+func (h stubResourceHandler) Map(name string, resource ManifestResource, ctx *translationContext) (*RadiusResource, error) {
+	return &RadiusResource{
+		BicepIdentifier: name,
+		RuntimeName:     name,
+		RadiusType:      string(h.kind),
+		APIVersion:      apiVersion,
+		Kind:            h.kind,
+	}, nil
+}
+
+func (h stubResourceHandler) Resolve(expr *AspireExpression, ctx *translationContext) (string, *ConnectionSpec, error) {
+	return "resolved:" + expr.ResourceName, nil, nil
+}
+
+func TestHandlerForType_PrefixMatching(t *testing.T) {
+	t.Parallel()
+
+	handler := stubResourceHandler{kind: ResourceKind("Test.Acme/widgets")}
+	RegisterResourceHandler("acme.widget.v", handler)
+
+	if _, ok := handlerForType("other.v0"); ok {
+		t.Fatal("expected no handler for an unrelated type")
+	}
+
+	got, ok := handlerForType("acme.widget.v0")
+	if !ok {
+		t.Fatal("expected a handler for acme.widget.v0")
+	}
+
+	if got.Kind(ManifestResource{}) != handler.kind {
+		t.Errorf("got kind %q, want %q", got.Kind(ManifestResource{}), handler.kind)
+	}
+}
+
+func TestHandlerForType_LongestPrefixWins(t *testing.T) {
+	t.Parallel()
+
+	broad := stubResourceHandler{kind: ResourceKind("Test.Acme/generic")}
+	specific := stubResourceHandler{kind: ResourceKind("Test.Acme/cache")}
+
+	RegisterResourceHandler("acme.cache.", broad)
+	RegisterResourceHandler("acme.cache.v0", specific)
+
+	got, ok := handlerForType("acme.cache.v0")
+	if !ok {
+		t.Fatal("expected a handler match")
+	}
+
+	if got.Kind(ManifestResource{}) != specific.kind {
+		t.Errorf("expected the more specific handler to win, got kind %q", got.Kind(ManifestResource{}))
+	}
+}
+
+func TestClassify_CustomResourceHandler(t *testing.T) {
+	t.Parallel()
+
+	customKind := ResourceKind("Test.Acme/pubsub")
+	RegisterResourceHandler("acme.pubsub.v0", stubResourceHandler{kind: customKind})
+
+	resource := ManifestResource{Type: "acme.pubsub.v0"}
+	if got := classify("bus", resource, nil, nil); got != customKind {
+		t.Errorf("classify() = %q, want %q", got, customKind)
+	}
+}
+
+func TestMapResources_CustomResourceHandler(t *testing.T) {
+	t.Parallel()
+
+	customKind := ResourceKind("Test.Acme/pubsub")
+	RegisterResourceHandler("acme.pubsub2.v0", stubResourceHandler{kind: customKind})
+
+	ctx := newTranslationContext(&AspireManifest{
+		Resources: map[string]ManifestResource{
+			"bus": {Type: "acme.pubsub2.v0"},
+		},
+	}, &translationConfig{})
+	ctx.nameMap["bus"] = "bus"
+	ctx.kindMap["bus"] = customKind
+	ctx.handlerMap["bus"] = stubResourceHandler{kind: customKind}
+
+	if err := mapResources(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mapped, ok := ctx.resources["bus"]
+	if !ok {
+		t.Fatal("expected the custom handler to map a resource")
+	}
+
+	if mapped.Kind != customKind {
+		t.Errorf("mapped.Kind = %q, want %q", mapped.Kind, customKind)
+	}
+}
+
+func TestResolveSingleExpression_CustomResourceHandler(t *testing.T) {
+	t.Parallel()
+
+	customKind := ResourceKind("Test.Acme/pubsub")
+	ctx := &translationContext{
+		handlerMap: map[string]ResourceHandler{
+			"bus": stubResourceHandler{kind: customKind},
+		},
+	}
+
+	expr := &AspireExpression{ResourceName: "bus", PropertyPath: []string{"topic"}}
+
+	value, conn, err := resolveSingleExpression(expr, "app", "bus", customKind, ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if conn != nil {
+		t.Errorf("expected no connection spec, got %+v", conn)
+	}
+
+	if value != "resolved:bus" {
+		t.Errorf("value = %q, want %q", value, "resolved:bus")
+	}
+}
+
+func TestClassify_UnregisteredAzureType(t *testing.T) {
+	t.Parallel()
+
+	resource := ManifestResource{Type: "azure.foo.v0"}
+	if got := classify("thing", resource, nil, nil); got != KindUnsupported {
+		t.Errorf("classify() = %q, want %q", got, KindUnsupported)
+	}
+}
+
+func TestAzureManagedServiceHandler_Map(t *testing.T) {
+	t.Parallel()
+
+	handler, ok := handlerForType("azure.storage.blob.v0")
+	if !ok {
+		t.Fatal("expected a handler for azure.storage.blob.v0")
+	}
+
+	ctx := newTranslationContext(&AspireManifest{}, &translationConfig{})
+	ctx.nameMap["storage"] = "storage"
+
+	mapped, err := handler.Map("storage", ManifestResource{Type: "azure.storage.blob.v0"}, ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if mapped.Kind != KindExtender {
+		t.Errorf("mapped.Kind = %q, want %q", mapped.Kind, KindExtender)
+	}
+
+	if mapped.Extender == nil || mapped.Extender.Resource != "<YOUR_STORAGE_ACCOUNT_RESOURCE_ID>" {
+		t.Errorf("mapped.Extender = %+v, want placeholder resource ID", mapped.Extender)
+	}
+
+	if len(ctx.warnings) != 1 {
+		t.Fatalf("expected one warning, got %d: %v", len(ctx.warnings), ctx.warnings)
+	}
+}
+
+func TestAzureManagedServiceHandler_Resolve(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		typ      string
+		property string
+		want     string
+	}{
+		{name: "blob endpoint", typ: "azure.storage.blob.v0", property: "blobEndpoint", want: "${storage.properties.blobEndpoint}"},
+		{name: "service bus endpoint", typ: "azure.servicebus.v0", property: "endpoint", want: "${bus.properties.endpoint}"},
+		{name: "key vault uri", typ: "azure.keyvault.v0", property: "vaultUri", want: "${kv.properties.vaultUri}"},
+		{name: "unrecognized property falls back to id", typ: "azure.keyvault.v0", property: "unknownProp", want: "${kv.id}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			handler, ok := handlerForType(tt.typ)
+			if !ok {
+				t.Fatalf("expected a handler for %s", tt.typ)
+			}
+
+			ctx := newTranslationContext(&AspireManifest{}, &translationConfig{})
+			resourceName := "kv"
+			switch tt.typ {
+			case "azure.storage.blob.v0":
+				resourceName = "storage"
+			case "azure.servicebus.v0":
+				resourceName = "bus"
+			}
+			ctx.nameMap[resourceName] = resourceName
+
+			expr := &AspireExpression{ResourceName: resourceName, PropertyPath: []string{tt.property}}
+
+			value, conn, err := handler.Resolve(expr, ctx)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if value != tt.want {
+				t.Errorf("value = %q, want %q", value, tt.want)
+			}
+
+			if conn == nil || !conn.IsBicepReference {
+				t.Errorf("expected a Bicep-reference connection, got %+v", conn)
+			}
+		})
+	}
+}
@@ -0,0 +1,491 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ReverseOptions configures TranslateReverse.
+type ReverseOptions struct {
+	// BicepPath is the file path to a Radius Bicep file, normally one previously
+	// generated by Translate with EmitFormat FormatBicep.
+	BicepPath string
+}
+
+// ReverseResult is the result of TranslateReverse.
+type ReverseResult struct {
+	// Manifest is the reconstructed Aspire manifest.
+	Manifest *AspireManifest
+
+	// ManifestJSON is Manifest marshaled as Aspire manifest JSON, ready to write to an
+	// aspire-manifest.json file.
+	ManifestJSON []byte
+
+	// Warnings lists information from the Bicep source that could not be recovered
+	// faithfully, e.g. a parameter-sourced image that has no literal value to restore,
+	// or an env var sourced from a secret store key.
+	Warnings []string
+}
+
+// reverseResourceBlock is one `resource <id> '<type>@<version>' = { ... }` declaration
+// recovered from a Bicep file, with its body still in raw text form for the
+// per-kind reverse mappers below to pick apart.
+type reverseResourceBlock struct {
+	identifier string
+	radiusType string
+	body       string
+}
+
+// reverseResourceHeaderPattern matches a top-level Bicep resource declaration header,
+// capturing its Bicep identifier and fully qualified Radius type (version dropped).
+var reverseResourceHeaderPattern = regexp.MustCompile(`(?m)^resource\s+(\w+)\s+'([^'@]+)@[^']*'\s*=\s*\{`)
+
+// reverseNamePattern matches a resource's `name: '...'` property, which TranslateReverse
+// treats as the resource's Aspire runtime name.
+var reverseNamePattern = regexp.MustCompile(`name:\s*'([^']*)'`)
+
+// reverseImageLiteralPattern matches a container's `image: '...'` property.
+var reverseImageLiteralPattern = regexp.MustCompile(`image:\s*'([^']*)'`)
+
+// reverseImageParamPattern matches a container's `image: <expr>` property when the
+// value isn't a quoted literal, i.e. it's a Bicep parameter reference or expression.
+var reverseImageParamPattern = regexp.MustCompile(`image:\s*([A-Za-z_][\w.]*)`)
+
+// reversePortEntryPattern matches one `<bindingName>: { containerPort: N, ... }` entry
+// inside a container's ports block.
+var reversePortEntryPattern = regexp.MustCompile(`(\w+):\s*\{\s*containerPort:\s*(\d+)([^}]*)\}`)
+
+// reversePortSchemePattern matches the optional `scheme: '...'` field within one port entry.
+var reversePortSchemePattern = regexp.MustCompile(`scheme:\s*'([^']*)'`)
+
+// reverseEnvEntryPattern matches one `<name>: { value: '...' }` entry inside a
+// container's env block. Entries using `valueFrom.secretRef` (secret-backed env vars)
+// don't match this pattern and are reported as a warning instead.
+var reverseEnvEntryPattern = regexp.MustCompile(`(\w+):\s*\{\s*value:\s*'([^']*)'\s*\}`)
+
+// reverseEnvSecretEntryPattern matches one secret-backed `<name>: { valueFrom: { secretRef: ... } }`
+// entry, so TranslateReverse can at least name the variable in a warning.
+var reverseEnvSecretEntryPattern = regexp.MustCompile(`(\w+):\s*\{\s*valueFrom:`)
+
+// reverseRouteDestinationPattern matches a gateway route's `destination: 'http://<id>:<port>'`
+// property, used to recover which container a route points at.
+var reverseRouteDestinationPattern = regexp.MustCompile(`destination:\s*'https?://([^:/']+):(\d+)[^']*'`)
+
+// reverseRouteHostnamePattern matches a gateway route's optional `hostname: '...'` property.
+var reverseRouteHostnamePattern = regexp.MustCompile(`hostname:\s*'([^']*)'`)
+
+// reverseRoutePathPattern matches a gateway route's `path: '...'` property.
+var reverseRoutePathPattern = regexp.MustCompile(`path:\s*'([^']*)'`)
+
+// reverseBackingServiceImages picks a representative Aspire container image for each
+// portable resource kind TranslateReverse can recover, i.e. the inverse of
+// backingServiceTable's prefix match.
+var reverseBackingServiceImages = map[ResourceKind]string{
+	KindRedisCache: "redis:latest",
+	KindSQLDB:      "postgres:latest",
+	KindMongoDB:    "mongo:latest",
+	KindRabbitMQ:   "rabbitmq:latest",
+}
+
+// TranslateReverse parses a Radius Bicep file and reconstructs the closest-fit Aspire
+// manifest that would translate to it, for round-tripping a deployed application back
+// into an editable Aspire manifest.
+//
+// This is a narrow, pragmatic parser tailored to the textual shape emit produces (see
+// emittemplates.go and bicepFuncMap's block helpers), not a general Bicep parser: it
+// recognizes top-level `resource <id> '<type>@<version>' = { ... }` declarations and
+// picks a handful of well-known properties out of each one by pattern, rather than
+// building a full expression-aware AST. Bicep hand-written or reformatted outside of
+// Translate's own output is not guaranteed to parse.
+//
+// Recoverable per resource kind:
+//   - Applications.Core/containers: image (literal only; a parameter-sourced image is
+//     reported as a warning and left blank), ports (as bindings), and literal-value env
+//     vars (secret-backed env vars are reported as a warning and dropped).
+//   - Applications.Datastores/*, Applications.Messaging/*: reconstructed as a
+//     container.v0 resource whose image is a representative name for the kind (e.g.
+//     "redis:latest"), since that's what detectBackingService would classify back to
+//     the same kind. The original image reference is not recoverable from Bicep, since
+//     emit never round-trips it into recipe parameters beyond ImageDigest.
+//   - Applications.Core/gateways: each route becomes `external: true` plus `host`/`path`
+//     overrides on the binding it targets, recovered by matching the route's
+//     `destination` against a container's Bicep identifier.
+//
+// Anything else (Applications.Core/secretStores, Applications.Core/services,
+// Applications.Core/extenders, and any resource type this parser doesn't recognize) is
+// left out of the reconstructed manifest and reported as a warning, since Aspire has no
+// equivalent resource type to reconstruct them into.
+func TranslateReverse(opts ReverseOptions) (*ReverseResult, error) {
+	data, err := os.ReadFile(opts.BicepPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("bicep file not found: %s", opts.BicepPath)
+		}
+
+		return nil, fmt.Errorf("failed to read bicep file: %w", err)
+	}
+
+	blocks, err := splitResourceBlocks(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &AspireManifest{Resources: map[string]ManifestResource{}}
+	var warnings []string
+
+	// containerRuntimeNames maps a container's Bicep identifier to its Aspire resource
+	// name, so a gateway route's destination (which references the Bicep identifier) can
+	// be resolved back to the binding it should mark external.
+	containerRuntimeNames := map[string]string{}
+
+	var gatewayBlocks []reverseResourceBlock
+
+	for _, block := range blocks {
+		name := reverseResourceName(block)
+
+		switch ResourceKind(block.radiusType) {
+		case KindApplication, KindSecretStore, KindService, KindExtender:
+			warnings = append(warnings, fmt.Sprintf("resource %q (%s) has no Aspire equivalent and was dropped", name, block.radiusType))
+			continue
+
+		case KindContainer:
+			resource, containerWarnings := reverseContainer(block)
+			manifest.Resources[name] = resource
+			containerRuntimeNames[block.identifier] = name
+			warnings = append(warnings, containerWarnings...)
+
+		case KindGateway:
+			// Deferred until every container has been recorded, so routes can resolve
+			// their destination's Bicep identifier to a runtime name.
+			gatewayBlocks = append(gatewayBlocks, block)
+
+		case KindRedisCache, KindSQLDB, KindMongoDB, KindRabbitMQ:
+			image, ok := reverseBackingServiceImages[ResourceKind(block.radiusType)]
+			if !ok {
+				warnings = append(warnings, fmt.Sprintf("resource %q has unrecognized portable resource type %q and was dropped", name, block.radiusType))
+				continue
+			}
+
+			manifest.Resources[name] = ManifestResource{Type: "container.v0", Image: image}
+			warnings = append(warnings, fmt.Sprintf("resource %q (%s): original backing-service image reference is not preserved by Bicep emission; reconstructed using the placeholder image %q", name, block.radiusType, image))
+
+		default:
+			warnings = append(warnings, fmt.Sprintf("resource %q has unrecognized type %q and was dropped", name, block.radiusType))
+		}
+	}
+
+	for _, block := range gatewayBlocks {
+		warnings = append(warnings, reverseGateway(block, manifest, containerRuntimeNames)...)
+	}
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal reconstructed manifest: %w", err)
+	}
+
+	return &ReverseResult{
+		Manifest:     manifest,
+		ManifestJSON: manifestJSON,
+		Warnings:     warnings,
+	}, nil
+}
+
+// reverseResourceName returns the Aspire resource name for block: its `name: '...'`
+// property when present, falling back to its Bicep identifier.
+func reverseResourceName(block reverseResourceBlock) string {
+	if m := reverseNamePattern.FindStringSubmatch(block.body); m != nil {
+		return m[1]
+	}
+
+	return block.identifier
+}
+
+// reverseContainer reconstructs a container.v0 ManifestResource from a parsed
+// Applications.Core/containers block.
+func reverseContainer(block reverseResourceBlock) (ManifestResource, []string) {
+	name := reverseResourceName(block)
+	resource := ManifestResource{Type: "container.v0"}
+	var warnings []string
+
+	if m := reverseImageLiteralPattern.FindStringSubmatch(block.body); m != nil {
+		resource.Image = m[1]
+	} else if m := reverseImageParamPattern.FindStringSubmatch(block.body); m != nil {
+		warnings = append(warnings, fmt.Sprintf("resource %q: image is sourced from parameter/expression %q, which has no recoverable literal value", name, m[1]))
+	}
+
+	if ports := reversePortsBlock(block.body); len(ports) > 0 {
+		resource.Bindings = ports
+	}
+
+	env, interpolated := reverseEnvBlock(block.body)
+	if len(env) > 0 {
+		resource.Env = env
+	}
+	for _, envName := range interpolated {
+		warnings = append(warnings, fmt.Sprintf("resource %q: env var %q resolves to a Bicep expression referencing another resource, which has no recoverable Aspire binding/connection-string expression", name, envName))
+	}
+
+	for _, m := range reverseEnvSecretEntryPattern.FindAllStringSubmatch(block.body, -1) {
+		warnings = append(warnings, fmt.Sprintf("resource %q: env var %q is sourced from a secret store key, which TranslateReverse cannot recover a value for", name, m[1]))
+	}
+
+	return resource, warnings
+}
+
+// reversePortsBlock extracts the container's ports block (see renderPortBlock) into
+// Aspire manifest bindings.
+func reversePortsBlock(body string) map[string]ManifestBinding {
+	portsIdx := strings.Index(body, "ports:")
+	if portsIdx < 0 {
+		return nil
+	}
+
+	bindings := map[string]ManifestBinding{}
+
+	for _, m := range reversePortEntryPattern.FindAllStringSubmatch(body[portsIdx:], -1) {
+		port, err := strconv.Atoi(m[2])
+		if err != nil {
+			continue
+		}
+
+		binding := ManifestBinding{Port: port, TargetPort: port}
+		if sm := reversePortSchemePattern.FindStringSubmatch(m[3]); sm != nil {
+			binding.Scheme = sm[1]
+		}
+
+		bindings[m[1]] = binding
+	}
+
+	return bindings
+}
+
+// reverseEnvBlock extracts the container's env block (see renderEnvBlock) into Aspire
+// manifest env vars, skipping secret-backed entries (reported separately as a warning by
+// the caller) and returning the names of any entries whose value is a Bicep string
+// interpolation (e.g. '${cache.id}') rather than a plain literal: emit() only produces
+// those for a resolved binding/connection-string reference, and the specific Aspire
+// expression that resolved to it (which property, of which original resource name) isn't
+// recoverable from the interpolated output alone.
+func reverseEnvBlock(body string) (env map[string]string, interpolated []string) {
+	envIdx := strings.Index(body, "env:")
+	if envIdx < 0 {
+		return nil, nil
+	}
+
+	env = map[string]string{}
+
+	for _, m := range reverseEnvEntryPattern.FindAllStringSubmatch(body[envIdx:], -1) {
+		if strings.Contains(m[2], "${") {
+			interpolated = append(interpolated, m[1])
+			continue
+		}
+
+		env[m[1]] = m[2]
+	}
+
+	return env, interpolated
+}
+
+// reverseGateway recovers `external: true` (plus host/path overrides) on the bindings a
+// gateway's routes target, returning any warnings for routes that couldn't be resolved
+// back to a known container.
+func reverseGateway(block reverseResourceBlock, manifest *AspireManifest, containerRuntimeNames map[string]string) []string {
+	name := reverseResourceName(block)
+	var warnings []string
+
+	routesIdx := strings.Index(block.body, "routes:")
+	if routesIdx < 0 {
+		return warnings
+	}
+
+	routeBodies := splitBracketedItems(block.body[routesIdx:])
+
+	for _, routeBody := range routeBodies {
+		m := reverseRouteDestinationPattern.FindStringSubmatch(routeBody)
+		if m == nil {
+			warnings = append(warnings, fmt.Sprintf("gateway %q: a route has no recoverable destination (likely a redirect route) and was dropped", name))
+			continue
+		}
+
+		targetID, port := m[1], m[2]
+
+		runtimeName, ok := containerRuntimeNames[targetID]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("gateway %q: route destination %q does not match any known container and was dropped", name, targetID))
+			continue
+		}
+
+		target, ok := manifest.Resources[runtimeName]
+		if !ok {
+			continue
+		}
+
+		bindingName := reverseBindingNameForPort(target.Bindings, port)
+		if bindingName == "" {
+			continue
+		}
+
+		binding := target.Bindings[bindingName]
+		binding.External = true
+
+		if hm := reverseRouteHostnamePattern.FindStringSubmatch(routeBody); hm != nil {
+			binding.Host = hm[1]
+		}
+		if pm := reverseRoutePathPattern.FindStringSubmatch(routeBody); pm != nil && pm[1] != "/" {
+			binding.Path = pm[1]
+		}
+
+		target.Bindings[bindingName] = binding
+		manifest.Resources[runtimeName] = target
+	}
+
+	return warnings
+}
+
+// reverseBindingNameForPort returns the name of the binding in bindings whose port
+// matches portStr, or "" if none match.
+func reverseBindingNameForPort(bindings map[string]ManifestBinding, portStr string) string {
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return ""
+	}
+
+	var names []string
+	for name := range bindings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if bindings[name].Port == port || bindings[name].TargetPort == port {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// splitResourceBlocks scans src for top-level `resource <id> '<type>@<version>' = { ... }`
+// declarations and returns each one with its body (the text between the matched braces).
+func splitResourceBlocks(src string) ([]reverseResourceBlock, error) {
+	var blocks []reverseResourceBlock
+
+	for _, loc := range reverseResourceHeaderPattern.FindAllStringSubmatchIndex(src, -1) {
+		identifier := src[loc[2]:loc[3]]
+		radiusType := src[loc[4]:loc[5]]
+		openBrace := loc[1] - 1
+
+		end, err := matchBrace(src, openBrace)
+		if err != nil {
+			return nil, fmt.Errorf("resource %q: %w", identifier, err)
+		}
+
+		blocks = append(blocks, reverseResourceBlock{
+			identifier: identifier,
+			radiusType: radiusType,
+			body:       src[openBrace+1 : end],
+		})
+	}
+
+	return blocks, nil
+}
+
+// matchBrace returns the index of the '}' that closes the '{' at src[open], accounting
+// for nested braces.
+func matchBrace(src string, open int) (int, error) {
+	depth := 0
+
+	for i := open; i < len(src); i++ {
+		switch src[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("unbalanced braces")
+}
+
+// splitBracketedItems splits the first top-level `[ ... ]` array found in body into its
+// comma-separated `{ ... }` object items, used to walk a gateway's routes array one
+// route at a time.
+func splitBracketedItems(body string) []string {
+	start := strings.Index(body, "[")
+	if start < 0 {
+		return nil
+	}
+
+	end, err := matchBracket(body, start)
+	if err != nil {
+		return nil
+	}
+
+	inner := body[start+1 : end]
+
+	var items []string
+	depth := 0
+	itemStart := -1
+
+	for i, r := range inner {
+		switch r {
+		case '{':
+			if depth == 0 {
+				itemStart = i
+			}
+			depth++
+		case '}':
+			depth--
+			if depth == 0 && itemStart >= 0 {
+				items = append(items, inner[itemStart:i+1])
+				itemStart = -1
+			}
+		}
+	}
+
+	return items
+}
+
+// matchBracket returns the index of the ']' that closes the '[' at src[open],
+// accounting for nesting.
+func matchBracket(src string, open int) (int, error) {
+	depth := 0
+
+	for i := open; i < len(src); i++ {
+		switch src[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("unbalanced brackets")
+}
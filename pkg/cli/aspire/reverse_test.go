@@ -0,0 +1,240 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeBicepForReverse translates manifestPath and writes the result to a scratch file
+// in t.TempDir(), returning its path for TranslateReverse to consume.
+func writeBicepForReverse(t *testing.T, manifestPath string, opts TranslateOptions) string {
+	t.Helper()
+
+	opts.ManifestPath = manifestPath
+
+	result, err := Translate(opts)
+	if err != nil {
+		t.Fatalf("Translate failed: %v", err)
+	}
+
+	bicepPath := filepath.Join(t.TempDir(), "app.bicep")
+	if err := os.WriteFile(bicepPath, []byte(result.Bicep), 0o644); err != nil {
+		t.Fatalf("failed to write scratch bicep file: %v", err)
+	}
+
+	return bicepPath
+}
+
+func TestTranslateReverse_SimpleContainers(t *testing.T) {
+	t.Parallel()
+
+	bicepPath := writeBicepForReverse(t, filepath.Join("testdata", "simple-containers.json"), TranslateOptions{AppName: "app"})
+
+	result, err := TranslateReverse(ReverseOptions{BicepPath: bicepPath})
+	if err != nil {
+		t.Fatalf("TranslateReverse failed: %v", err)
+	}
+
+	if len(result.Manifest.Resources) == 0 {
+		t.Fatal("expected at least one recovered resource")
+	}
+
+	for name, resource := range result.Manifest.Resources {
+		if resource.Type != "container.v0" {
+			t.Errorf("resource %q: expected container.v0, got %q", name, resource.Type)
+		}
+		if resource.Image == "" {
+			t.Errorf("resource %q: expected a recovered image", name)
+		}
+	}
+}
+
+func TestTranslateReverse_BackingServices(t *testing.T) {
+	t.Parallel()
+
+	bicepPath := writeBicepForReverse(t, filepath.Join("testdata", "backing-services.json"), TranslateOptions{AppName: "app"})
+
+	result, err := TranslateReverse(ReverseOptions{BicepPath: bicepPath})
+	if err != nil {
+		t.Fatalf("TranslateReverse failed: %v", err)
+	}
+
+	foundPlaceholderWarning := false
+	for _, w := range result.Warnings {
+		if strings.Contains(w, "placeholder image") {
+			foundPlaceholderWarning = true
+			break
+		}
+	}
+	if !foundPlaceholderWarning {
+		t.Errorf("expected a warning about a reconstructed placeholder image, got %v", result.Warnings)
+	}
+
+	foundBackingService := false
+	for _, resource := range result.Manifest.Resources {
+		if resource.Type == "container.v0" && (resource.Image == "redis:latest" || resource.Image == "postgres:latest") {
+			foundBackingService = true
+			break
+		}
+	}
+	if !foundBackingService {
+		t.Errorf("expected a reconstructed backing-service container, got %+v", result.Manifest.Resources)
+	}
+}
+
+func TestTranslateReverse_Gateway(t *testing.T) {
+	t.Parallel()
+
+	bicepPath := writeBicepForReverse(t, filepath.Join("testdata", "gateway.json"), TranslateOptions{AppName: "app"})
+
+	result, err := TranslateReverse(ReverseOptions{BicepPath: bicepPath})
+	if err != nil {
+		t.Fatalf("TranslateReverse failed: %v", err)
+	}
+
+	foundExternalBinding := false
+	for _, resource := range result.Manifest.Resources {
+		for _, binding := range resource.Bindings {
+			if binding.External {
+				foundExternalBinding = true
+			}
+		}
+	}
+	if !foundExternalBinding {
+		t.Errorf("expected a gateway route to recover as an external binding, got %+v", result.Manifest.Resources)
+	}
+}
+
+func TestTranslateReverse_FullAppRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	bicepPath := writeBicepForReverse(t, filepath.Join("testdata", "full-app.json"), TranslateOptions{
+		AppName: "fullapp",
+		ImageMappings: map[string]string{
+			"api":    "myregistry.io/api:v1.0",
+			"worker": "myregistry.io/worker:v1.0",
+		},
+	})
+
+	reversed, err := TranslateReverse(ReverseOptions{BicepPath: bicepPath})
+	if err != nil {
+		t.Fatalf("TranslateReverse failed: %v", err)
+	}
+
+	if len(reversed.ManifestJSON) == 0 {
+		t.Fatal("expected non-empty reconstructed manifest JSON")
+	}
+
+	reconstructedPath := filepath.Join(t.TempDir(), "aspire-manifest.json")
+	if err := os.WriteFile(reconstructedPath, reversed.ManifestJSON, 0o644); err != nil {
+		t.Fatalf("failed to write reconstructed manifest: %v", err)
+	}
+
+	retranslated, err := Translate(TranslateOptions{ManifestPath: reconstructedPath, AppName: "fullapp"})
+	if err != nil {
+		t.Fatalf("re-translating reconstructed manifest failed: %v", err)
+	}
+
+	original, err := os.ReadFile(bicepPath)
+	if err != nil {
+		t.Fatalf("failed to read original bicep file: %v", err)
+	}
+
+	// api and worker are project.v0/v1 resources whose ImageMappings image is emitted as a
+	// literal, so TranslateReverse recovers them as container.v0 resources; the gateway and
+	// container resource types should still be present in both Bicep outputs.
+	for _, id := range []string{"Applications.Core/gateways", "Applications.Core/containers"} {
+		if strings.Contains(string(original), id) && !strings.Contains(retranslated.Bicep, id) {
+			t.Errorf("expected re-translated Bicep to still contain %q", id)
+		}
+	}
+
+	if len(reversed.Warnings) == 0 {
+		t.Error("expected warnings for information full-app.json loses on the Bicep round trip")
+	}
+}
+
+func TestTranslateReverse_FileNotFound(t *testing.T) {
+	t.Parallel()
+
+	_, err := TranslateReverse(ReverseOptions{BicepPath: filepath.Join(t.TempDir(), "missing.bicep")})
+	if err == nil {
+		t.Fatal("expected an error for a missing bicep file")
+	}
+}
+
+func TestTranslateReverse_SecretBackedEnvVarWarning(t *testing.T) {
+	t.Parallel()
+
+	bicep := `
+resource api 'Applications.Core/containers@2023-10-01-preview' = {
+  name: 'api'
+  properties: {
+    image: 'myimage:latest'
+    container: {
+      env: {
+        PLAIN: {
+          value: 'ok'
+        }
+        TOKEN: {
+          valueFrom: {
+            secretRef: {
+              source: 'secrets'
+              key: 'token'
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`
+	bicepPath := filepath.Join(t.TempDir(), "app.bicep")
+	if err := os.WriteFile(bicepPath, []byte(bicep), 0o644); err != nil {
+		t.Fatalf("failed to write scratch bicep file: %v", err)
+	}
+
+	result, err := TranslateReverse(ReverseOptions{BicepPath: bicepPath})
+	if err != nil {
+		t.Fatalf("TranslateReverse failed: %v", err)
+	}
+
+	resource, ok := result.Manifest.Resources["api"]
+	if !ok {
+		t.Fatal("expected resource \"api\" to be recovered")
+	}
+	if resource.Env["PLAIN"] != "ok" {
+		t.Errorf("expected PLAIN env var to be recovered, got %q", resource.Env["PLAIN"])
+	}
+	if _, ok := resource.Env["TOKEN"]; ok {
+		t.Error("expected secret-backed TOKEN env var to be dropped, not recovered as a literal")
+	}
+
+	foundSecretWarning := false
+	for _, w := range result.Warnings {
+		if strings.Contains(w, "TOKEN") {
+			foundSecretWarning = true
+		}
+	}
+	if !foundSecretWarning {
+		t.Errorf("expected a warning naming the secret-backed env var, got %v", result.Warnings)
+	}
+}
@@ -0,0 +1,295 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// runtimeOptionHandler mutates a ContainerSpec for a single recognized docker-create-style
+// flag. value is the text after "=" (or the following array element, for flags that take a
+// separate value); it is empty for boolean flags that take no value.
+type runtimeOptionHandler func(container *ContainerSpec, value string) error
+
+// runtimeOptionTable maps recognized docker-create-style flag names to their handler.
+// Unrecognized flags are reported as warnings by applyRuntimeOptions rather than silently
+// dropped.
+var runtimeOptionTable = map[string]runtimeOptionHandler{
+	"--memory":       setMemoryLimit,
+	"-m":             setMemoryLimit,
+	"--cpus":         setCPULimit,
+	"--cap-add":      addCapability,
+	"--security-opt": addSecurityOpt,
+	"--sysctl":       addSysctl,
+	"--ulimit":       addUlimit,
+	"--restart":      setRestartPolicy,
+	"--user":         setRunAsUser,
+	"-u":             setRunAsUser,
+	"--hostname":     setHostname,
+	"--tmpfs":        addTmpfsMount,
+	"--volume":       addBindMountOption,
+	"-v":             addBindMountOption,
+	"--health-cmd":   setHealthCmd,
+	"--health-interval": func(c *ContainerSpec, v string) error {
+		return setHealthDuration(c, v, func(p *ProbeSpec, seconds int) { p.PeriodSeconds = seconds })
+	},
+	"--health-start-period": func(c *ContainerSpec, v string) error {
+		return setHealthDuration(c, v, func(p *ProbeSpec, seconds int) { p.InitialDelaySeconds = seconds })
+	},
+	"--health-retries": setHealthRetries,
+}
+
+// applyRuntimeOptions parses a resource's docker-create-style Options and applies the
+// recognized ones to container. Flags it doesn't recognize (or that are missing a required
+// value) are returned as warning messages rather than silently dropped.
+func applyRuntimeOptions(container *ContainerSpec, options []string) []string {
+	var warnings []string
+
+	for i := 0; i < len(options); i++ {
+		flag := options[i]
+
+		name := flag
+		value := ""
+		hasInline := false
+
+		if idx := strings.Index(flag, "="); idx >= 0 && strings.HasPrefix(flag, "-") {
+			name = flag[:idx]
+			value = flag[idx+1:]
+			hasInline = true
+		}
+
+		handler, ok := runtimeOptionTable[name]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("unsupported container runtime option %q", flag))
+			continue
+		}
+
+		if !hasInline {
+			if i+1 >= len(options) {
+				warnings = append(warnings, fmt.Sprintf("%s requires a value", name))
+				continue
+			}
+
+			i++
+			value = options[i]
+		}
+
+		if err := handler(container, value); err != nil {
+			warnings = append(warnings, err.Error())
+		}
+	}
+
+	return warnings
+}
+
+func setMemoryLimit(container *ContainerSpec, value string) error {
+	if container.Resources == nil {
+		container.Resources = &ContainerResourcesSpec{}
+	}
+
+	container.Resources.Memory = value
+
+	return nil
+}
+
+func setCPULimit(container *ContainerSpec, value string) error {
+	if container.Resources == nil {
+		container.Resources = &ContainerResourcesSpec{}
+	}
+
+	container.Resources.CPU = value
+
+	return nil
+}
+
+func addCapability(container *ContainerSpec, value string) error {
+	ensureSecurityContext(container).CapAdd = append(ensureSecurityContext(container).CapAdd, value)
+	return nil
+}
+
+func addSecurityOpt(container *ContainerSpec, value string) error {
+	sc := ensureSecurityContext(container)
+	sc.SecurityOpt = append(sc.SecurityOpt, value)
+
+	return nil
+}
+
+func addSysctl(container *ContainerSpec, value string) error {
+	key, val, ok := strings.Cut(value, "=")
+	if !ok {
+		return fmt.Errorf("--sysctl %q is not in key=value form", value)
+	}
+
+	sc := ensureSecurityContext(container)
+	if sc.Sysctls == nil {
+		sc.Sysctls = make(map[string]string)
+	}
+
+	sc.Sysctls[key] = val
+
+	return nil
+}
+
+func addUlimit(container *ContainerSpec, value string) error {
+	sc := ensureSecurityContext(container)
+	sc.Ulimits = append(sc.Ulimits, value)
+
+	return nil
+}
+
+func setRestartPolicy(container *ContainerSpec, value string) error {
+	container.RestartPolicy = value
+	return nil
+}
+
+func setRunAsUser(container *ContainerSpec, value string) error {
+	ensureSecurityContext(container).RunAsUser = value
+	return nil
+}
+
+func setHostname(container *ContainerSpec, value string) error {
+	container.Hostname = value
+	return nil
+}
+
+// addTmpfsMount adds a tmpfs mount (e.g. "/tmp" or "/tmp:rw,noexec") as an ephemeral
+// container volume, in the same vein as a BindMount.
+func addTmpfsMount(container *ContainerSpec, value string) error {
+	path := value
+	if idx := strings.Index(value, ":"); idx >= 0 {
+		path = value[:idx]
+	}
+
+	if path == "" {
+		return fmt.Errorf("--tmpfs requires a mount path")
+	}
+
+	if container.Volumes == nil {
+		container.Volumes = make(map[string]VolumeSpec)
+	}
+
+	container.Volumes[sanitize(path)] = VolumeSpec{
+		Kind:      "ephemeral",
+		MountPath: path,
+	}
+
+	return nil
+}
+
+// addBindMountOption adds a docker-create-style "--volume"/"-v" bind mount
+// ("/host/path:/container/path[:ro]") as a container volume, in the same vein as the
+// manifest's own BindMounts field.
+func addBindMountOption(container *ContainerSpec, value string) error {
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 {
+		return fmt.Errorf("--volume %q must be in host:container[:ro] form", value)
+	}
+
+	target := parts[1]
+	readOnly := len(parts) >= 3 && parts[2] == "ro"
+
+	if container.Volumes == nil {
+		container.Volumes = make(map[string]VolumeSpec)
+	}
+
+	container.Volumes[sanitize(target)] = VolumeSpec{
+		Kind:      "ephemeral",
+		MountPath: target,
+		ReadOnly:  readOnly,
+	}
+
+	return nil
+}
+
+func setHealthCmd(container *ContainerSpec, value string) error {
+	probe := ensureLivenessProbe(container)
+	probe.Kind = "exec"
+	probe.Command = []string{"CMD-SHELL", value}
+
+	return nil
+}
+
+// setHealthDuration parses a Docker-style duration (e.g. "30s", "1m") into whole seconds
+// and applies it via set.
+func setHealthDuration(container *ContainerSpec, value string, set func(probe *ProbeSpec, seconds int)) error {
+	seconds, err := parseDurationSeconds(value)
+	if err != nil {
+		return err
+	}
+
+	set(ensureLivenessProbe(container), seconds)
+
+	return nil
+}
+
+func setHealthRetries(container *ContainerSpec, value string) error {
+	retries, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("--health-retries value %q is not a valid integer: %w", value, err)
+	}
+
+	ensureLivenessProbe(container).FailureThreshold = retries
+
+	return nil
+}
+
+// parseDurationSeconds parses a Docker-style duration suffixed with "s", "m", or "h"
+// (e.g. "30s", "1m", "2h") into whole seconds. A bare integer is treated as seconds.
+func parseDurationSeconds(value string) (int, error) {
+	if value == "" {
+		return 0, fmt.Errorf("duration value is empty")
+	}
+
+	unit := value[len(value)-1]
+	multiplier := 1
+
+	switch unit {
+	case 's':
+		value = value[:len(value)-1]
+	case 'm':
+		multiplier = 60
+		value = value[:len(value)-1]
+	case 'h':
+		multiplier = 3600
+		value = value[:len(value)-1]
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %w", value, err)
+	}
+
+	return n * multiplier, nil
+}
+
+func ensureSecurityContext(container *ContainerSpec) *SecurityContextSpec {
+	if container.SecurityContext == nil {
+		container.SecurityContext = &SecurityContextSpec{}
+	}
+
+	return container.SecurityContext
+}
+
+func ensureLivenessProbe(container *ContainerSpec) *ProbeSpec {
+	if container.LivenessProbe == nil {
+		container.LivenessProbe = &ProbeSpec{}
+	}
+
+	return container.LivenessProbe
+}
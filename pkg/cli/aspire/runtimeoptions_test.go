@@ -0,0 +1,232 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import "testing"
+
+func TestApplyRuntimeOptions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("resources and security context", func(t *testing.T) {
+		t.Parallel()
+
+		container := &ContainerSpec{}
+		warnings := applyRuntimeOptions(container, []string{
+			"--memory=512m",
+			"--cpus=0.5",
+			"--cap-add", "NET_ADMIN",
+			"--security-opt", "no-new-privileges",
+			"--sysctl", "net.core.somaxconn=1024",
+			"--ulimit", "nofile=1024:2048",
+			"--user", "1000:1000",
+			"--hostname", "myhost",
+			"--restart", "always",
+		})
+
+		if len(warnings) != 0 {
+			t.Fatalf("unexpected warnings: %v", warnings)
+		}
+
+		if container.Resources == nil || container.Resources.Memory != "512m" || container.Resources.CPU != "0.5" {
+			t.Errorf("unexpected resources: %+v", container.Resources)
+		}
+
+		if container.SecurityContext == nil {
+			t.Fatal("expected a security context")
+		}
+
+		sc := container.SecurityContext
+		if len(sc.CapAdd) != 1 || sc.CapAdd[0] != "NET_ADMIN" {
+			t.Errorf("unexpected CapAdd: %v", sc.CapAdd)
+		}
+		if len(sc.SecurityOpt) != 1 || sc.SecurityOpt[0] != "no-new-privileges" {
+			t.Errorf("unexpected SecurityOpt: %v", sc.SecurityOpt)
+		}
+		if sc.Sysctls["net.core.somaxconn"] != "1024" {
+			t.Errorf("unexpected Sysctls: %v", sc.Sysctls)
+		}
+		if len(sc.Ulimits) != 1 || sc.Ulimits[0] != "nofile=1024:2048" {
+			t.Errorf("unexpected Ulimits: %v", sc.Ulimits)
+		}
+		if sc.RunAsUser != "1000:1000" {
+			t.Errorf("expected RunAsUser '1000:1000', got %q", sc.RunAsUser)
+		}
+
+		if container.Hostname != "myhost" {
+			t.Errorf("expected hostname 'myhost', got %q", container.Hostname)
+		}
+
+		if container.RestartPolicy != "always" {
+			t.Errorf("expected restart policy 'always', got %q", container.RestartPolicy)
+		}
+	})
+
+	t.Run("health check flags build a liveness probe", func(t *testing.T) {
+		t.Parallel()
+
+		container := &ContainerSpec{}
+		warnings := applyRuntimeOptions(container, []string{
+			"--health-cmd=curl -f http://localhost/health || exit 1",
+			"--health-interval=30s",
+			"--health-start-period=1m",
+			"--health-retries=3",
+		})
+
+		if len(warnings) != 0 {
+			t.Fatalf("unexpected warnings: %v", warnings)
+		}
+
+		probe := container.LivenessProbe
+		if probe == nil {
+			t.Fatal("expected a liveness probe")
+		}
+
+		if probe.Kind != "exec" {
+			t.Errorf("expected exec probe, got %q", probe.Kind)
+		}
+		if len(probe.Command) != 2 || probe.Command[1] != "curl -f http://localhost/health || exit 1" {
+			t.Errorf("unexpected probe command: %v", probe.Command)
+		}
+		if probe.PeriodSeconds != 30 {
+			t.Errorf("expected period 30s, got %d", probe.PeriodSeconds)
+		}
+		if probe.InitialDelaySeconds != 60 {
+			t.Errorf("expected initial delay 60s, got %d", probe.InitialDelaySeconds)
+		}
+		if probe.FailureThreshold != 3 {
+			t.Errorf("expected 3 retries, got %d", probe.FailureThreshold)
+		}
+	})
+
+	t.Run("tmpfs mount becomes an ephemeral volume", func(t *testing.T) {
+		t.Parallel()
+
+		container := &ContainerSpec{}
+		warnings := applyRuntimeOptions(container, []string{"--tmpfs", "/tmp:rw,noexec"})
+
+		if len(warnings) != 0 {
+			t.Fatalf("unexpected warnings: %v", warnings)
+		}
+
+		vol, ok := container.Volumes[sanitize("/tmp")]
+		if !ok {
+			t.Fatal("expected a volume for /tmp")
+		}
+		if vol.MountPath != "/tmp" || vol.Kind != "ephemeral" {
+			t.Errorf("unexpected volume: %+v", vol)
+		}
+	})
+
+	t.Run("volume flag becomes a bind-mount volume", func(t *testing.T) {
+		t.Parallel()
+
+		container := &ContainerSpec{}
+		warnings := applyRuntimeOptions(container, []string{"--volume", "/host/data:/data:ro", "-v", "/host/cache:/cache"})
+
+		if len(warnings) != 0 {
+			t.Fatalf("unexpected warnings: %v", warnings)
+		}
+
+		data, ok := container.Volumes[sanitize("/data")]
+		if !ok || !data.ReadOnly || data.MountPath != "/data" {
+			t.Errorf("expected a read-only /data volume, got %+v (ok=%v)", data, ok)
+		}
+
+		cache, ok := container.Volumes[sanitize("/cache")]
+		if !ok || cache.ReadOnly || cache.MountPath != "/cache" {
+			t.Errorf("expected a writable /cache volume, got %+v (ok=%v)", cache, ok)
+		}
+	})
+
+	t.Run("malformed volume flag produces a warning", func(t *testing.T) {
+		t.Parallel()
+
+		container := &ContainerSpec{}
+		warnings := applyRuntimeOptions(container, []string{"--volume", "/data"})
+
+		if len(warnings) != 1 {
+			t.Fatalf("expected exactly one warning, got: %v", warnings)
+		}
+	})
+
+	t.Run("unrecognized flag produces a warning", func(t *testing.T) {
+		t.Parallel()
+
+		container := &ContainerSpec{}
+		warnings := applyRuntimeOptions(container, []string{"--network=host"})
+
+		if len(warnings) != 1 {
+			t.Fatalf("expected 1 warning, got %v", warnings)
+		}
+	})
+
+	t.Run("flag missing a required value produces a warning", func(t *testing.T) {
+		t.Parallel()
+
+		container := &ContainerSpec{}
+		warnings := applyRuntimeOptions(container, []string{"--memory"})
+
+		if len(warnings) != 1 {
+			t.Fatalf("expected 1 warning, got %v", warnings)
+		}
+	})
+
+	t.Run("malformed sysctl produces a warning", func(t *testing.T) {
+		t.Parallel()
+
+		container := &ContainerSpec{}
+		warnings := applyRuntimeOptions(container, []string{"--sysctl", "not-a-key-value"})
+
+		if len(warnings) != 1 {
+			t.Fatalf("expected 1 warning, got %v", warnings)
+		}
+	})
+
+	t.Run("no options is a no-op", func(t *testing.T) {
+		t.Parallel()
+
+		container := &ContainerSpec{}
+		warnings := applyRuntimeOptions(container, nil)
+
+		if len(warnings) != 0 {
+			t.Fatalf("unexpected warnings: %v", warnings)
+		}
+	})
+}
+
+func TestMapContainer_WithOptions(t *testing.T) {
+	t.Parallel()
+
+	resource := ManifestResource{
+		Type:    "container.v0",
+		Image:   "myapp:latest",
+		Options: []string{"--memory=256m", "--bogus-flag"},
+	}
+
+	result, _, warnings, err := mapContainer("test", resource, "test", &translationContext{config: &translationConfig{}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Container.Resources == nil || result.Container.Resources.Memory != "256m" {
+		t.Errorf("unexpected resources: %+v", result.Container.Resources)
+	}
+
+	if len(warnings) != 1 {
+		t.Fatalf("expected 1 warning for the unrecognized flag, got %v", warnings)
+	}
+}
@@ -21,15 +21,28 @@ import (
 	"strings"
 )
 
-// sanitize converts an Aspire resource name to a valid Bicep identifier.
+// sanitize converts an Aspire resource name to a valid Bicep identifier. It never touches
+// the original name used elsewhere for diagnostics and the Radius "name" property
+// (RadiusResource.RuntimeName) â€” callers that need to match a warning or error message
+// back to the manifest should keep using the original, unsanitized name.
 //
 // Rules:
-//  1. Replace hyphens with underscores
+//  1. Replace hyphens and dots with underscores
 //  2. Remove characters not in [a-zA-Z0-9_]
 //  3. If the result starts with a digit, prepend "r_"
+//
+// Two distinct manifest names can sanitize to the same identifier (e.g. "foo-bar" and
+// "foo.bar" both become "foo_bar" once dashes and dots are folded to the same
+// underscore separator). sanitizeAll reports that as an identifierCollisionError rather
+// than silently disambiguating with a numeric suffix, matching how this package treats
+// every other ambiguous-mapping case (unresolved expression references, duplicate resource
+// names) as a hard error the user must fix in the manifest instead of a warning.
+// sanitizeAllForWorkspace does the same for two names from one manifest, but prefixes
+// colliding names from different manifests with their module name.
 func sanitize(name string) string {
-	// Replace hyphens with underscores.
-	result := strings.ReplaceAll(name, "-", "_")
+	// Replace hyphens and dots with underscores so names that differ only in
+	// separator style (e.g. "foo-bar" vs "foo.bar") normalize identically.
+	result := strings.NewReplacer("-", "_", ".", "_").Replace(name)
 
 	// Remove all characters that are not alphanumeric or underscores.
 	re := regexp.MustCompile(`[^a-zA-Z0-9_]`)
@@ -71,3 +84,49 @@ func sanitizeAll(names []string) (map[string]string, error) {
 
 	return result, nil
 }
+
+// sanitizeAllForWorkspace is like sanitizeAll, but for multi-manifest workspaces: when two
+// resources from different manifests sanitize to the same Bicep identifier, both are
+// namespaced with their source manifest's module name (see manifestModuleName) instead of
+// returning an identifierCollisionError. A collision between two resources from the same
+// manifest is still a genuine error and is reported as such.
+func sanitizeAllForWorkspace(names []string, origin map[string]string) (map[string]string, error) {
+	modules := make(map[string]struct{})
+	for _, name := range names {
+		modules[origin[name]] = struct{}{}
+	}
+
+	if len(modules) <= 1 {
+		return sanitizeAll(names)
+	}
+
+	bySanitized := make(map[string][]string) // sanitized identifier -> original names
+	for _, name := range names {
+		sanitized := sanitize(name)
+		bySanitized[sanitized] = append(bySanitized[sanitized], name)
+	}
+
+	result := make(map[string]string, len(names))
+	reverse := make(map[string]string, len(names)) // sanitized → original
+
+	for _, name := range names {
+		sanitized := sanitize(name)
+
+		if len(bySanitized[sanitized]) > 1 {
+			sanitized = manifestModuleName(origin[name]) + "_" + sanitized
+		}
+
+		if existing, ok := reverse[sanitized]; ok {
+			return nil, &identifierCollisionError{
+				name1:      existing,
+				name2:      name,
+				identifier: sanitized,
+			}
+		}
+
+		result[name] = sanitized
+		reverse[sanitized] = name
+	}
+
+	return result, nil
+}
@@ -14,23 +14,6 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package aspire
-/*
-Copyright 2023 The Radius Authors.
-
-Licensed under the Apache License, Version 2.0 (the "License");
-you may not use this file except in compliance with the License.
-You may obtain a copy of the License at
-
-    http://www.apache.org/licenses/LICENSE-2.0
-
-Unless required by applicable law or agreed to in writing, software
-distributed under the License is distributed on an "AS IS" BASIS,
-WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-See the License for the specific language governing permissions and
-limitations under the License.
-*/
-
 package aspire
 
 import "testing"
@@ -46,11 +29,16 @@ func TestSanitize(t *testing.T) {
 		{name: "basic name", input: "api", expected: "api"},
 		{name: "hyphens to underscores", input: "api-service", expected: "api_service"},
 		{name: "leading digit", input: "1cache", expected: "r_1cache"},
-		{name: "invalid characters", input: "my.resource@name", expected: "myresourcename"},
+		{name: "invalid characters", input: "my.resource@name", expected: "my_resourcename"},
 		{name: "multiple hyphens", input: "my-cool-service", expected: "my_cool_service"},
 		{name: "already valid", input: "myService", expected: "myService"},
 		{name: "underscore preserved", input: "my_service", expected: "my_service"},
 		{name: "all special chars", input: "!!!", expected: "r_unnamed"},
+		{name: "dotted name", input: "foo.bar", expected: "foo_bar"},
+		{name: "dashed name", input: "foo-bar", expected: "foo_bar"},
+		{name: "leading digit, no prefix", input: "123foo", expected: "r_123foo"},
+		{name: "leading digit with suffix", input: "123svc", expected: "r_123svc"},
+		{name: "empty name", input: "", expected: "r_unnamed"},
 	}
 
 	for _, tt := range tests {
@@ -93,4 +81,30 @@ func TestSanitizeAll(t *testing.T) {
 			t.Fatalf("expected identifierCollisionError, got %T", err)
 		}
 	})
+
+	t.Run("collision between dotted and dashed names", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := sanitizeAll([]string{"my.api-frontend", "my-api-frontend"})
+		if err == nil {
+			t.Fatal("expected collision error")
+		}
+
+		if _, ok := err.(*identifierCollisionError); !ok {
+			t.Fatalf("expected identifierCollisionError, got %T", err)
+		}
+	})
+
+	t.Run("collision between foo-bar and foo.bar", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := sanitizeAll([]string{"foo-bar", "foo.bar"})
+		if err == nil {
+			t.Fatal("expected collision error")
+		}
+
+		if _, ok := err.(*identifierCollisionError); !ok {
+			t.Fatalf("expected identifierCollisionError, got %T", err)
+		}
+	})
 }
@@ -0,0 +1,137 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+//go:embed schemas/*.json
+var manifestSchemasFS embed.FS
+
+// manifestSchemas maps a manifest's "$schema" value to the embedded JSON Schema document
+// that describes it. Unlike manifestDecoders, which only needs to know a schema well
+// enough to decode it into AspireManifest, this registry is used to validate the raw
+// manifest JSON itself, so it only needs an entry for schema versions worth checking
+// structurally. A schema URL absent here falls back to permissive mode (see
+// validateManifestSchema).
+var manifestSchemas = map[string]string{
+	"https://json.schemastore.org/aspire-8.0.json": "schemas/aspire-8.0.json",
+	"https://json.schemastore.org/aspire-8.1.json": "schemas/aspire-8.1.json",
+	"https://json.schemastore.org/aspire-8.2.json": "schemas/aspire-8.2.json",
+}
+
+// validateManifestSchema validates ctx.manifest.RawJSON against the JSON Schema document
+// registered for ctx.manifest.Schema, recording one schemaViolationError per violation in
+// ctx.errors so the caller sees every problem at once instead of only the first.
+//
+// A manifest with no RawJSON (built from a Compose file, or merged from a multi-manifest
+// workspace) is never validated, since there's no longer a single JSON document to check.
+// A manifest whose "$schema" is empty or not in manifestSchemas falls back to permissive
+// mode (the pre-existing behavior of skipping validation entirely), recorded as a warning
+// unless ctx.config.strictSchema is set, in which case it's a hard error instead.
+func validateManifestSchema(ctx *translationContext) {
+	manifest := ctx.manifest
+	if len(manifest.RawJSON) == 0 {
+		return
+	}
+
+	schemaPath, ok := manifestSchemas[manifest.Schema]
+	if !ok {
+		msg := fmt.Sprintf("manifest schema %q is not recognized; skipping schema validation", manifest.Schema)
+		if ctx.config.strictSchema {
+			ctx.addError(fmt.Errorf("%s", msg))
+		} else {
+			ctx.addWarning(msg)
+		}
+
+		return
+	}
+
+	schema, err := compileManifestSchema(manifest.Schema, schemaPath)
+	if err != nil {
+		ctx.addError(err)
+		return
+	}
+
+	var instance any
+	if err := json.Unmarshal(manifest.RawJSON, &instance); err != nil {
+		ctx.addError(fmt.Errorf("failed to parse manifest for schema validation: %w", err))
+		return
+	}
+
+	err = schema.Validate(instance)
+	if err == nil {
+		return
+	}
+
+	validationErr, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		ctx.addError(err)
+		return
+	}
+
+	for _, violation := range flattenSchemaViolations(validationErr) {
+		ctx.addError(violation)
+	}
+}
+
+// compileManifestSchema compiles the embedded JSON Schema document at schemasFSPath,
+// registered under schemaURL so $ref resolution within the document resolves locally.
+func compileManifestSchema(schemaURL, schemasFSPath string) (*jsonschema.Schema, error) {
+	text, err := manifestSchemasFS.ReadFile(schemasFSPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedded schema %q: %w", schemasFSPath, err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(schemaURL, bytes.NewReader(text)); err != nil {
+		return nil, fmt.Errorf("failed to load schema %q: %w", schemaURL, err)
+	}
+
+	schema, err := compiler.Compile(schemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile schema %q: %w", schemaURL, err)
+	}
+
+	return schema, nil
+}
+
+// flattenSchemaViolations walks a jsonschema.ValidationError's Causes tree and returns one
+// schemaViolationError per leaf, so a manifest with several unrelated problems (e.g. two
+// resources each missing a different required field) surfaces all of them instead of just
+// the outermost summary.
+func flattenSchemaViolations(err *jsonschema.ValidationError) []*schemaViolationError {
+	if len(err.Causes) == 0 {
+		return []*schemaViolationError{{
+			path:    err.InstanceLocation,
+			message: err.Message,
+		}}
+	}
+
+	var violations []*schemaViolationError
+	for _, cause := range err.Causes {
+		violations = append(violations, flattenSchemaViolations(cause)...)
+	}
+
+	return violations
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"errors"
+	"testing"
+)
+
+func newTestContextWithRawJSON(schema string, rawJSON string, strictSchema bool) *translationContext {
+	return &translationContext{
+		config: &translationConfig{strictSchema: strictSchema},
+		manifest: &AspireManifest{
+			Schema:  schema,
+			RawJSON: []byte(rawJSON),
+		},
+	}
+}
+
+func TestValidateManifestSchema_Valid(t *testing.T) {
+	t.Parallel()
+
+	ctx := newTestContextWithRawJSON(
+		"https://json.schemastore.org/aspire-8.0.json",
+		`{"$schema":"https://json.schemastore.org/aspire-8.0.json","resources":{"api":{"type":"container.v0","image":"api:latest"}}}`,
+		false,
+	)
+
+	validateManifestSchema(ctx)
+
+	if len(ctx.errors) != 0 {
+		t.Fatalf("expected no errors, got %v", ctx.errors)
+	}
+}
+
+func TestValidateManifestSchema_MissingRequiredField(t *testing.T) {
+	t.Parallel()
+
+	ctx := newTestContextWithRawJSON(
+		"https://json.schemastore.org/aspire-8.0.json",
+		`{"$schema":"https://json.schemastore.org/aspire-8.0.json","resources":{"api":{"image":"api:latest"}}}`,
+		false,
+	)
+
+	validateManifestSchema(ctx)
+
+	if len(ctx.errors) == 0 {
+		t.Fatal("expected a violation for the resource missing its required 'type' field")
+	}
+
+	var violation *schemaViolationError
+	if !errors.As(ctx.errors[0], &violation) {
+		t.Fatalf("expected schemaViolationError, got %T: %v", ctx.errors[0], ctx.errors[0])
+	}
+}
+
+func TestValidateManifestSchema_WrongFieldType(t *testing.T) {
+	t.Parallel()
+
+	ctx := newTestContextWithRawJSON(
+		"https://json.schemastore.org/aspire-8.2.json",
+		`{"$schema":"https://json.schemastore.org/aspire-8.2.json","resources":{"api":{"type":"container.v0","image":123}}}`,
+		false,
+	)
+
+	validateManifestSchema(ctx)
+
+	if len(ctx.errors) == 0 {
+		t.Fatal("expected a violation for 'image' not being a string")
+	}
+}
+
+func TestValidateManifestSchema_UnrecognizedSchemaIsPermissive(t *testing.T) {
+	t.Parallel()
+
+	ctx := newTestContextWithRawJSON(
+		"https://json.schemastore.org/aspire-99.9.json",
+		`{"$schema":"https://json.schemastore.org/aspire-99.9.json","resources":{"api":{"image":123}}}`,
+		false,
+	)
+
+	validateManifestSchema(ctx)
+
+	if len(ctx.errors) != 0 {
+		t.Fatalf("expected no errors in permissive mode, got %v", ctx.errors)
+	}
+
+	if len(ctx.warnings) != 1 {
+		t.Fatalf("expected 1 warning, got %d: %v", len(ctx.warnings), ctx.warnings)
+	}
+}
+
+func TestValidateManifestSchema_UnrecognizedSchemaIsHardErrorWhenStrict(t *testing.T) {
+	t.Parallel()
+
+	ctx := newTestContextWithRawJSON(
+		"https://json.schemastore.org/aspire-99.9.json",
+		`{"$schema":"https://json.schemastore.org/aspire-99.9.json","resources":{"api":{"image":123}}}`,
+		true,
+	)
+
+	validateManifestSchema(ctx)
+
+	if len(ctx.errors) != 1 {
+		t.Fatalf("expected 1 error when StrictSchema is set, got %d: %v", len(ctx.errors), ctx.errors)
+	}
+
+	if len(ctx.warnings) != 0 {
+		t.Fatalf("expected no warnings when StrictSchema upgrades it to an error, got %v", ctx.warnings)
+	}
+}
+
+func TestValidateManifestSchema_SkipsManifestsWithoutRawJSON(t *testing.T) {
+	t.Parallel()
+
+	ctx := &translationContext{
+		config: &translationConfig{},
+		manifest: &AspireManifest{
+			Schema: "https://json.schemastore.org/aspire-8.0.json",
+		},
+	}
+
+	validateManifestSchema(ctx)
+
+	if len(ctx.errors) != 0 || len(ctx.warnings) != 0 {
+		t.Fatalf("expected no errors or warnings without RawJSON, got errors=%v warnings=%v", ctx.errors, ctx.warnings)
+	}
+}
@@ -21,21 +21,71 @@ import (
 	"sort"
 )
 
-// Translate is the top-level entry point. It reads the manifest, runs the full
+// Translate is the top-level entry point. It reads one or more manifests (see
+// TranslateOptions.ManifestPaths, WorkspacePath, and ComposePath), runs the full
 // translation pipeline, and returns the generated Bicep text.
 func Translate(opts TranslateOptions) (*TranslateResult, error) {
-	// Parse the manifest.
-	manifest, err := parseManifest(opts.ManifestPath)
+	if opts.ComposePath != "" {
+		manifest, err := ComposeToManifest(opts.ComposePath)
+		if err != nil {
+			return nil, err
+		}
+
+		origin := make(map[string]string, len(manifest.Resources))
+		for name := range manifest.Resources {
+			origin[name] = opts.ComposePath
+		}
+
+		return translateManifest(manifest, origin, opts)
+	}
+
+	paths, err := resolveManifestPaths(opts)
 	if err != nil {
 		return nil, err
 	}
 
-	// Build config with defaults.
+	manifest, origin, err := loadManifests(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := translateManifest(manifest, origin, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Group the translated resources by source manifest for workspaces with more than
+	// one aspire-manifest.json.
+	if len(paths) > 1 {
+		result.Modules = buildModulesFromOrigin(origin)
+	}
+
+	return result, nil
+}
+
+// newTranslationConfig builds a translationConfig from opts, filling in the same
+// defaults Translate and Lint both rely on.
+func newTranslationConfig(opts TranslateOptions) *translationConfig {
 	config := &translationConfig{
 		appName:           opts.AppName,
 		environmentName:   opts.EnvironmentName,
 		imageMappings:     opts.ImageMappings,
 		resourceOverrides: opts.ResourceOverrides,
+		buildMode:         opts.BuildMode,
+		registry:          opts.Registry,
+		pushImages:        opts.PushImages,
+		builderImage:      opts.BuilderImage,
+		registryResolver:  opts.RegistryResolver,
+		emitFormat:        opts.EmitFormat,
+		templateDir:       opts.TemplateDir,
+		gatewayMode:       opts.GatewayMode,
+		strictSchema:      opts.StrictSchema,
+		mode:              opts.Mode,
+		existingOutputDir: opts.ExistingOutputDir,
+		gatewayAPI:        opts.GatewayAPI,
+		manifestOverlays:  opts.ManifestOverlays,
+		secretBackend:     opts.SecretBackend,
+		secretStoreName:   opts.SecretStoreName,
 	}
 
 	if config.appName == "" {
@@ -46,8 +96,44 @@ func Translate(opts TranslateOptions) (*TranslateResult, error) {
 		config.environmentName = "default"
 	}
 
+	if config.buildMode == "" {
+		config.buildMode = BuildModeNone
+	}
+
+	if config.emitFormat == "" {
+		config.emitFormat = FormatBicep
+	}
+
+	if config.gatewayMode == "" {
+		config.gatewayMode = GatewayModeIngress
+	}
+
+	if config.mode == "" {
+		config.mode = ModeGenerate
+	}
+
+	return config
+}
+
+// translateManifest runs the translation pipeline over an already-parsed manifest.
+func translateManifest(manifest *AspireManifest, origin map[string]string, opts TranslateOptions) (*TranslateResult, error) {
+	config := newTranslationConfig(opts)
+
 	// Create translation context.
 	ctx := newTranslationContext(manifest, config)
+	ctx.origin = origin
+	ctx.env = opts.Env
+
+	// Surface any decode-time warnings (e.g. a resource type unrecognized by the
+	// manifest's schema decoder) accumulated while loading the manifest(s).
+	ctx.warnings = append(ctx.warnings, manifest.Warnings...)
+
+	// Validate the manifest against its declared Aspire JSON schema (if recognized)
+	// before anything downstream assumes a well-formed shape.
+	validateManifestSchema(ctx)
+	if len(ctx.errors) > 0 {
+		return nil, ctx.errors[0]
+	}
 
 	// Check for circular references.
 	if err := detectCircularReferences(ctx); err != nil {
@@ -77,6 +163,19 @@ func Translate(opts TranslateOptions) (*TranslateResult, error) {
 		return nil, err
 	}
 
+	// Phase 3b: Merge manifest overlays into their target containers before expression
+	// resolution, so overlay env values get resolved the same way manifest-native ones do.
+	if err := applyManifestOverlays(ctx); err != nil {
+		return nil, err
+	}
+
+	// Synthesize the secret store (if any) before resolving expressions, so env vars that
+	// reference secret parameters can be wired to it via valueFrom.secretRef.
+	secretStore := synthesizeSecretStore(ctx)
+	if secretStore != nil {
+		ctx.resources["secretstore"] = secretStore
+	}
+
 	// Phase 4: Resolve expressions.
 	resolveExpressions(ctx)
 
@@ -86,20 +185,90 @@ func Translate(opts TranslateOptions) (*TranslateResult, error) {
 		return nil, ctx.errors[0]
 	}
 
-	// Phase 5: Synthesize gateway if needed.
-	gateway := synthesizeGateway(ctx)
-	if gateway != nil {
-		ctx.resources["gateway"] = gateway
-	}
-
-	// Phase 6: Emit Bicep.
-	bicep, err := emit(ctx)
+	// Phase 5: Synthesize gateway/service resources for external bindings, per GatewayMode.
+	gatewayResources, err := synthesizeGateway(ctx)
 	if err != nil {
 		return nil, err
 	}
+	for _, gr := range gatewayResources {
+		ctx.resources[gr.BicepIdentifier] = gr
+	}
+
+	// Phase 6: Emit. FormatContainerAppYAML and FormatKubernetesYAML each render their own
+	// YAML instead of Bicep, leaving Bicep empty in the result; FormatBicepAndContainerAppYAML
+	// renders both from this single pass.
+	var bicep string
+	var containerAppYAML, kubernetesYAML, helmChart map[string]string
+	switch config.emitFormat {
+	case FormatContainerAppYAML:
+		yaml, err := emitContainerAppYAML(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		containerAppYAML = yaml
+	case FormatKubernetesYAML:
+		yaml, err := emitKubernetesYAML(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		kubernetesYAML = yaml
+	case FormatHelm:
+		chart, err := emitHelmChart(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		helmChart = chart
+	case FormatBicepAndContainerAppYAML:
+		rendered, err := emit(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		yaml, err := emitContainerAppYAML(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		bicep = rendered
+		containerAppYAML = yaml
+	default:
+		rendered, err := emit(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		bicep = rendered
+	}
 
 	// Build the result.
-	result := buildResult(ctx, bicep, gateway)
+	result := buildResult(ctx, bicep, gatewayResources)
+	result.ContainerAppYAML = containerAppYAML
+	result.KubernetesYAML = kubernetesYAML
+	result.HelmChart = helmChart
+
+	// Generate the companion Tekton pipeline when building project resources that way.
+	if config.buildMode == BuildModeTektonPipeline && len(ctx.tektonProjects) > 0 {
+		pipeline, err := generateTektonPipeline(config.appName, ctx.tektonProjects)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Pipeline = pipeline
+	}
+
+	// In ModeDrift, report how the regenerated Bicep differs from ExistingOutputDir
+	// instead of expecting the caller to overwrite it.
+	if config.mode == ModeDrift {
+		drift, err := detectDrift(ctx, bicep)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Drift = drift
+	}
 
 	return result, nil
 }
@@ -107,9 +276,16 @@ func Translate(opts TranslateOptions) (*TranslateResult, error) {
 // classifyResources classifies all manifest resources and populates kindMap.
 func classifyResources(ctx *translationContext) {
 	for name, resource := range ctx.manifest.Resources {
-		kind := classify(name, resource, ctx.config.resourceOverrides)
+		kind := classify(name, resource, ctx.config, ctx.addWarning)
 		ctx.kindMap[name] = kind
 
+		// Record the handler that produced this kind (if any), so mapResources and
+		// resolveSingleExpression can fall back to it for kinds outside the built-in
+		// container/portable/value/parameter categories they already handle directly.
+		if handler, ok := handlerForType(resource.Type); ok {
+			ctx.handlerMap[name] = handler
+		}
+
 		if kind == KindUnsupported {
 			ctx.addWarning(fmt.Sprintf("Skipping unrecognized resource type %q for resource %q", resource.Type, name))
 		}
@@ -120,7 +296,7 @@ func classifyResources(ctx *translationContext) {
 func sanitizeIdentifiers(ctx *translationContext) error {
 	var names []string
 	for name, kind := range ctx.kindMap {
-		if kind != KindUnsupported && kind != KindValueResource && kind != KindParameter {
+		if kind != KindUnsupported && kind != KindValueResource && kind != KindParameter && kind != KindBuildOutput {
 			names = append(names, name)
 		}
 	}
@@ -128,7 +304,7 @@ func sanitizeIdentifiers(ctx *translationContext) error {
 	// Sort for deterministic ordering.
 	sort.Strings(names)
 
-	nameMap, err := sanitizeAll(names)
+	nameMap, err := sanitizeAllForWorkspace(names, ctx.origin)
 	if err != nil {
 		return err
 	}
@@ -141,73 +317,139 @@ func sanitizeIdentifiers(ctx *translationContext) error {
 // mapResources converts each classifed manifest resource into a RadiusResource.
 func mapResources(ctx *translationContext) error {
 	for name, kind := range ctx.kindMap {
-		resource := ctx.manifest.Resources[name]
+		if err := mapOneResource(name, kind, ctx); err != nil {
+			return err
+		}
+	}
 
-		switch {
-		case kind == KindContainer:
-			bicepID := ctx.nameMap[name]
-			mapped, err := mapContainer(name, resource, bicepID, ctx.config.imageMappings)
-			if err != nil {
-				return err
+	return nil
+}
+
+// mapOneResource converts a single classified manifest resource into a RadiusResource,
+// storing it in ctx.resources. Split out from mapResources so Lint's lintMapResources can
+// keep going after one resource fails to map, instead of mapResources' fail-fast behavior.
+func mapOneResource(name string, kind ResourceKind, ctx *translationContext) error {
+	resource := ctx.manifest.Resources[name]
+
+	switch {
+	case kind == KindContainer:
+		bicepID := ctx.nameMap[name]
+		mapped, builtImage, warnings, err := mapContainer(name, resource, bicepID, ctx)
+		if err != nil {
+			return err
+		}
+
+		ctx.resources[name] = mapped
+
+		for _, warning := range warnings {
+			ctx.addWarning(fmt.Sprintf("Resource %q: %s", name, warning))
+		}
+
+		if mapped.Container != nil && mapped.Container.ImageParam != "" {
+			ctx.parameters = append(ctx.parameters, BicepParameter{
+				Name:        mapped.Container.ImageParam,
+				Type:        "string",
+				Description: fmt.Sprintf("Container image for project resource %q (build and push it, e.g. with `dotnet publish /t:PublishContainer`, then supply its tag)", name),
+			})
+		}
+
+		if builtImage != "" {
+			ctx.builtImages[name] = builtImage
+
+			if ctx.config.buildMode == BuildModeTektonPipeline {
+				ctx.tektonProjects = append(ctx.tektonProjects, tektonProject{
+					BicepIdentifier: bicepID,
+					Image:           builtImage,
+					Path:            resource.Path,
+				})
 			}
+		}
+
+	case kind.IsPortableResource():
+		bicepID := ctx.nameMap[name]
+		mapped := mapPortableResource(name, kind, resource, bicepID)
+		ctx.resources[name] = mapped
+
+	case kind == KindValueResource:
+		// Value resources are inlined into consumers â€” no standalone resource.
 
-			ctx.resources[name] = mapped
+	case kind == KindBuildOutput:
+		// BuildOnly companions are inlined into their Parent project resource by
+		// mapContainer â€” no standalone resource.
 
-		case kind.IsPortableResource():
-			bicepID := ctx.nameMap[name]
-			mapped := mapPortableResource(name, kind, bicepID)
-			ctx.resources[name] = mapped
+	case kind == KindParameter:
+		// Parameter resources become Bicep parameters.
+		mapParameter(name, resource, ctx)
 
-		case kind == KindValueResource:
-			// Value resources are inlined into consumers â€” no standalone resource.
+	case kind == KindUnsupported:
+		// Already warned during classification.
 
-		case kind == KindParameter:
-			// Parameter resources become Bicep parameters.
-			mapParameter(name, resource, ctx)
+	default:
+		// A kind outside the built-in categories above: produced by a ResourceHandler
+		// registered for a custom Aspire resource type, so delegate mapping to it too.
+		if handler, ok := ctx.handlerMap[name]; ok {
+			mapped, err := handler.Map(name, resource, ctx)
+			if err != nil {
+				return err
+			}
 
-		case kind == KindUnsupported:
-			// Already warned during classification.
+			if mapped != nil {
+				ctx.resources[name] = mapped
+			}
 		}
 	}
 
 	return nil
 }
 
-// mapParameter converts a parameter.v0 resource to a BicepParameter.
+// mapParameter converts a parameter.v0 resource to a BicepParameter, unless it is marked
+// secret and config.secretBackend routes secrets to a synthesized secretStores resource
+// instead, in which case it is recorded in ctx.secretKeys. Secret parameters whose input
+// declared inputs.*.default.generate get a generated Bicep default (see
+// generatedDefaultExpression) rather than a literal one.
 func mapParameter(name string, resource ManifestResource, ctx *translationContext) {
 	sanitizedName := sanitize(name)
+	secret := isSecretParameter(resource)
+
+	if secret && ctx.config.secretBackend != "" && ctx.config.secretBackend != SecretBackendBicepParam {
+		ctx.secretKeys[sanitizedName] = resource.Value
+		return
+	}
 
 	param := BicepParameter{
 		Name:        sanitizedName,
 		Type:        "string",
 		Description: fmt.Sprintf("Parameter: %s", name),
+		Secure:      secret,
 	}
 
 	if resource.Value != "" {
 		param.DefaultValue = resource.Value
 	}
 
-	// Check inputs for secret flag.
-	if resource.Inputs != nil {
-		for _, input := range resource.Inputs {
-			if input.Secret {
-				param.Secure = true
-				break
-			}
-		}
+	if generate := parameterGenerateConfig(resource); secret && generate != nil {
+		// Aspire generates this value itself rather than supplying a literal default
+		// (resource.Value, if set, is typically a self-reference expression like
+		// "{dbPassword.inputs.value}" rather than a usable literal), so seed the Bicep
+		// parameter with an equivalent generated default instead.
+		param.DefaultValue = ""
+		param.DefaultExpression = generatedDefaultExpression()
 	}
 
 	ctx.parameters = append(ctx.parameters, param)
 }
 
 // buildResult constructs the TranslateResult from the translation context.
-func buildResult(ctx *translationContext, bicep string, gateway *RadiusResource) *TranslateResult {
+func buildResult(ctx *translationContext, bicep string, gatewayResources []*RadiusResource) *TranslateResult {
 	var resources []TranslatedResource
 
-	// Collect resource summaries in sorted order.
+	// Collect resource summaries in sorted order. Gateway/service/secret store resources
+	// are always synthesized, never assigned by classify(), so filtering on Kind here
+	// (rather than on the handful of reserved map keys they're registered under) is enough
+	// to keep them out of this pass; they get their own summaries below.
 	var names []string
-	for name := range ctx.resources {
-		if name == "gateway" {
+	for name, res := range ctx.resources {
+		if res.Kind == KindGateway || res.Kind == KindService || res.Kind == KindSecretStore {
 			continue
 		}
 
@@ -222,15 +464,28 @@ func buildResult(ctx *translationContext, bicep string, gateway *RadiusResource)
 			OriginalName:    name,
 			BicepIdentifier: res.BicepIdentifier,
 			Kind:            res.Kind,
+			BuiltImage:      ctx.builtImages[name],
+		})
+	}
+
+	// Add gateway/service resources synthesized for external bindings, tagged with the
+	// GatewayMode that produced them.
+	for _, gr := range gatewayResources {
+		resources = append(resources, TranslatedResource{
+			OriginalName:    gr.RuntimeName,
+			BicepIdentifier: gr.BicepIdentifier,
+			Kind:            gr.Kind,
+			Synthesized:     true,
+			Mode:            ctx.config.gatewayMode,
 		})
 	}
 
-	// Add gateway if present.
-	if gateway != nil {
+	// Add secret store if present.
+	if secretStore, ok := ctx.resources["secretstore"]; ok {
 		resources = append(resources, TranslatedResource{
-			OriginalName:    "gateway",
-			BicepIdentifier: "gateway",
-			Kind:            KindGateway,
+			OriginalName:    secretStore.RuntimeName,
+			BicepIdentifier: "secretstore",
+			Kind:            KindSecretStore,
 			Synthesized:     true,
 		})
 	}
@@ -277,15 +532,12 @@ func validateExpressionReferences(ctx *translationContext) error {
 		for _, value := range allValues {
 			cv := parseExpressions(value)
 			for _, part := range cv.parts {
-				if part.expression == nil {
-					continue
-				}
-
-				targetName := part.expression.ResourceName
-				if _, exists := ctx.manifest.Resources[targetName]; !exists {
-					return &unknownResourceError{
-						sourceResource: name,
-						targetResource: targetName,
+				for _, targetName := range collectValidatedRefs(part.expression) {
+					if _, exists := ctx.manifest.Resources[targetName]; !exists {
+						return &unknownResourceError{
+							sourceResource: name,
+							targetResource: targetName,
+						}
 					}
 				}
 			}
@@ -17,6 +17,7 @@ limitations under the License.
 package aspire
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -302,6 +303,103 @@ func TestTranslate_GatewayResult(t *testing.T) {
 	}
 }
 
+func TestTranslate_GatewayModes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ingress is the default", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := Translate(TranslateOptions{
+			ManifestPath: filepath.Join("testdata", "simple-containers.json"),
+			AppName:      "app",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !strings.Contains(result.Bicep, "Applications.Core/gateways@2023-10-01-preview") {
+			t.Error("expected default GatewayMode to synthesize a gateway resource")
+		}
+	})
+
+	t.Run("nodeport synthesizes services instead of a gateway", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := Translate(TranslateOptions{
+			ManifestPath: filepath.Join("testdata", "simple-containers.json"),
+			AppName:      "app",
+			GatewayMode:  GatewayModeNodePort,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if strings.Contains(result.Bicep, "Applications.Core/gateways@2023-10-01-preview") {
+			t.Error("expected GatewayModeNodePort not to synthesize a gateway resource")
+		}
+
+		if !strings.Contains(result.Bicep, "Applications.Core/services@2023-10-01-preview") {
+			t.Error("expected GatewayModeNodePort to synthesize a services resource")
+		}
+
+		var foundMode bool
+		for _, res := range result.Resources {
+			if res.Kind == KindService {
+				foundMode = true
+				if res.Mode != GatewayModeNodePort {
+					t.Errorf("expected synthesized service summary Mode %q, got %q", GatewayModeNodePort, res.Mode)
+				}
+			}
+		}
+		if !foundMode {
+			t.Error("expected a synthesized KindService entry in result.Resources")
+		}
+	})
+
+	t.Run("none annotates bindings with hostPort instead of synthesizing a resource", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := Translate(TranslateOptions{
+			ManifestPath: filepath.Join("testdata", "simple-containers.json"),
+			AppName:      "app",
+			GatewayMode:  GatewayModeNone,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if strings.Contains(result.Bicep, "Applications.Core/gateways@2023-10-01-preview") {
+			t.Error("expected GatewayModeNone not to synthesize a gateway resource")
+		}
+
+		if strings.Contains(result.Bicep, "Applications.Core/services@2023-10-01-preview") {
+			t.Error("expected GatewayModeNone not to synthesize a services resource")
+		}
+
+		if !strings.Contains(result.Bicep, "hostPort:") {
+			t.Error("expected GatewayModeNone to annotate external bindings with hostPort")
+		}
+	})
+
+	t.Run("unsupported mode is an error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Translate(TranslateOptions{
+			ManifestPath: filepath.Join("testdata", "simple-containers.json"),
+			AppName:      "app",
+			GatewayMode:  GatewayMode("bogus"),
+		})
+		if err == nil {
+			t.Fatal("expected an error for an unsupported GatewayMode")
+		}
+
+		var modeErr *unsupportedGatewayModeError
+		if !errors.As(err, &modeErr) {
+			t.Fatalf("expected unsupportedGatewayModeError, got %T: %v", err, err)
+		}
+	})
+}
+
 func TestTranslate_Projects(t *testing.T) {
 	t.Parallel()
 
@@ -368,6 +466,265 @@ func TestTranslate_ProjectMissingMapping(t *testing.T) {
 	}
 }
 
+// TestTranslate_ProjectWithBackingServiceConnections exercises a project.v0 resource
+// alongside a container.v0 backing service: a hyphenated name that needs sanitizing, a binding turned
+// into a container port, an env var that resolves into a connection to a sibling
+// container.v0 backing service, and an unknown runtime option that surfaces as a warning.
+func TestTranslate_ProjectWithBackingServiceConnections(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "aspire-manifest.json")
+	content := `{
+		"resources": {
+			"cache": {"type": "container.v0", "image": "redis:7.2"},
+			"web-api": {
+				"type": "project.v0",
+				"path": "src/web-api/web-api.csproj",
+				"env": {"CACHE_URL": "{cache.connectionString}"},
+				"bindings": {"http": {"scheme": "http", "protocol": "tcp", "port": 8080, "targetPort": 8080}},
+				"options": ["--bogus-flag"]
+			}
+		}
+	}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Translate(TranslateOptions{
+		ManifestPath: path,
+		AppName:      "app",
+		ImageMappings: map[string]string{
+			"web-api": "myregistry.io/web-api:v1.0",
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The hyphenated resource name is sanitized to a valid Bicep identifier.
+	var webAPI *TranslatedResource
+	for i := range result.Resources {
+		if result.Resources[i].OriginalName == "web-api" {
+			webAPI = &result.Resources[i]
+		}
+	}
+	if webAPI == nil {
+		t.Fatal("expected a translated resource for web-api")
+	}
+	if webAPI.BicepIdentifier != "web_api" {
+		t.Errorf("expected sanitized identifier %q, got %q", "web_api", webAPI.BicepIdentifier)
+	}
+
+	// The binding becomes a container port in the emitted Bicep.
+	if !strings.Contains(result.Bicep, "containerPort: 8080") {
+		t.Error("expected binding port 8080 in emitted Bicep")
+	}
+
+	// The env var resolves into a connection to the redis backing service.
+	if !strings.Contains(result.Bicep, "connections:") {
+		t.Error("expected a connections block referencing the cache backing service")
+	}
+
+	// The unrecognized runtime option surfaces as a non-fatal warning.
+	found := false
+	for _, w := range result.Warnings {
+		if strings.Contains(w, "web-api") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a warning about web-api's unrecognized runtime option, got %v", result.Warnings)
+	}
+}
+
+// TestTranslate_ProjectWithBuildOnlyCompanion exercises a project.v0 resource with no
+// ImageMappings entry but a sibling BuildOnly companion resource supplying its image:
+// the companion's image is used, the companion itself produces no standalone Bicep
+// resource, and no build-required warning is emitted.
+func TestTranslate_ProjectWithBuildOnlyCompanion(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "aspire-manifest.json")
+	content := `{
+		"resources": {
+			"api": {
+				"type": "project.v0",
+				"path": "src/api/api.csproj",
+				"bindings": {"http": {"scheme": "http", "protocol": "tcp", "port": 8080, "targetPort": 8080}}
+			},
+			"api-build": {
+				"type": "container.v0",
+				"buildOnly": true,
+				"parent": "api",
+				"image": "myregistry.io/api:sha-abc123"
+			}
+		}
+	}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Translate(TranslateOptions{
+		ManifestPath: path,
+		AppName:      "app",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(result.Bicep, "myregistry.io/api:sha-abc123") {
+		t.Error("expected the BuildOnly companion's image in the emitted Bicep")
+	}
+
+	for i := range result.Resources {
+		if result.Resources[i].OriginalName == "api-build" {
+			t.Errorf("expected no standalone resource for the BuildOnly companion, got %+v", result.Resources[i])
+		}
+	}
+
+	for _, w := range result.Warnings {
+		if strings.Contains(w, "build") {
+			t.Errorf("expected no build-required warning when a BuildOnly companion supplies the image, got %v", result.Warnings)
+		}
+	}
+}
+
+// TestTranslate_MixedProjectVersions exercises a manifest mixing project.v0 and
+// project.v1 resources: both are mapped identically (an ImageMappings entry for one, a
+// BuildOnly companion for the other), and project.v1's deployment metadata is accepted
+// without affecting the translation.
+func TestTranslate_MixedProjectVersions(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "aspire-manifest.json")
+	content := `{
+		"resources": {
+			"worker": {
+				"type": "project.v0",
+				"path": "src/worker/worker.csproj",
+				"bindings": {"http": {"scheme": "http", "protocol": "tcp", "port": 8080, "targetPort": 8080}}
+			},
+			"api": {
+				"type": "project.v1",
+				"path": "src/api/api.csproj",
+				"bindings": {"http": {"scheme": "http", "protocol": "tcp", "port": 8081, "targetPort": 8081}},
+				"deployment": {"profile": "production"}
+			},
+			"api-build": {
+				"type": "container.v0",
+				"buildOnly": true,
+				"parent": "api",
+				"image": "myregistry.io/api:sha-abc123"
+			}
+		}
+	}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Translate(TranslateOptions{
+		ManifestPath: path,
+		AppName:      "app",
+		ImageMappings: map[string]string{
+			"worker": "myregistry.io/worker:v1.0",
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	names := map[string]bool{}
+	for _, r := range result.Resources {
+		names[r.OriginalName] = true
+	}
+	if !names["worker"] || !names["api"] {
+		t.Fatalf("expected both worker (project.v0) and api (project.v1) to be translated, got %+v", result.Resources)
+	}
+	if names["api-build"] {
+		t.Error("expected no standalone resource for the BuildOnly companion")
+	}
+
+	if !strings.Contains(result.Bicep, "myregistry.io/worker:v1.0") {
+		t.Error("expected worker's ImageMappings entry in the emitted Bicep")
+	}
+	if !strings.Contains(result.Bicep, "myregistry.io/api:sha-abc123") {
+		t.Error("expected api's BuildOnly companion image in the emitted Bicep")
+	}
+
+	for _, w := range result.Warnings {
+		if strings.Contains(w, "build") {
+			t.Errorf("expected no build-required warning, got %v", result.Warnings)
+		}
+	}
+}
+
+// TestTranslate_FormatBicepAndContainerAppYAML exercises FormatBicepAndContainerAppYAML
+// against a manifest mixing a normal container, a BuildOnly companion, and an unrecognized
+// resource type: both outputs must be populated, the BuildOnly companion and the
+// unrecognized resource must be absent from both, and the "unrecognized resource type"
+// warning must appear regardless of which output is consulted.
+func TestTranslate_FormatBicepAndContainerAppYAML(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "aspire-manifest.json")
+	content := `{
+		"resources": {
+			"api": {
+				"type": "project.v0",
+				"path": "src/api",
+				"bindings": {"http": {"scheme": "http", "protocol": "tcp", "port": 8080, "targetPort": 8080}}
+			},
+			"api-build": {
+				"type": "container.v0",
+				"buildOnly": true,
+				"parent": "api",
+				"image": "myregistry.io/api:sha-abc123"
+			},
+			"mystery": {"type": "mystery.v1"}
+		}
+	}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Translate(TranslateOptions{
+		ManifestPath: path,
+		AppName:      "app",
+		EmitFormat:   FormatBicepAndContainerAppYAML,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Bicep == "" {
+		t.Error("expected non-empty Bicep output")
+	}
+
+	if len(result.ContainerAppYAML) != 1 {
+		t.Fatalf("expected exactly one Container Apps YAML manifest, got %v", result.ContainerAppYAML)
+	}
+
+	for _, name := range []string{"api-build", "mystery"} {
+		if strings.Contains(result.Bicep, name) {
+			t.Errorf("expected %q to be absent from Bicep output", name)
+		}
+	}
+
+	foundMysteryWarning := false
+	for _, w := range result.Warnings {
+		if strings.Contains(w, "mystery") {
+			foundMysteryWarning = true
+		}
+	}
+	if !foundMysteryWarning {
+		t.Errorf("expected a warning about the unrecognized resource type, got %v", result.Warnings)
+	}
+}
+
 func TestTranslate_FullApp(t *testing.T) {
 	t.Parallel()
 
@@ -753,3 +1110,183 @@ func TestTranslate_BicepStructure(t *testing.T) {
 		t.Error("containers should come before gateway")
 	}
 }
+
+func TestTranslate_SecretBackend(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "aspire-manifest.json")
+	content := `{
+		"resources": {
+			"dbPassword": {
+				"type": "parameter.v0",
+				"value": "generated-default",
+				"inputs": {"value": {"secret": true}}
+			},
+			"api": {
+				"type": "container.v0",
+				"image": "myapp/api:latest",
+				"env": {"DB_PASSWORD": "{dbPassword}"}
+			}
+		}
+	}`
+	if err := os.WriteFile(manifestPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+
+	t.Run("default backend emits a secure Bicep param", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := Translate(TranslateOptions{ManifestPath: manifestPath, AppName: "app"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !strings.Contains(result.Bicep, "@secure()") {
+			t.Error("expected a @secure() Bicep parameter")
+		}
+
+		if strings.Contains(result.Bicep, "Applications.Core/secretStores") {
+			t.Error("did not expect a secretStores resource for the default backend")
+		}
+	})
+
+	t.Run("radius-secretstore backend synthesizes a secret store", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := Translate(TranslateOptions{
+			ManifestPath:    manifestPath,
+			AppName:         "app",
+			SecretBackend:   SecretBackendRadiusSecretStore,
+			SecretStoreName: "app-secrets",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !strings.Contains(result.Bicep, "Applications.Core/secretStores@2023-10-01-preview") {
+			t.Error("expected a secretStores resource")
+		}
+
+		if !strings.Contains(result.Bicep, "valueFrom") || !strings.Contains(result.Bicep, "secretRef") {
+			t.Error("expected the container env var to reference the secret store")
+		}
+
+		if strings.Contains(result.Bicep, "@secure()") {
+			t.Error("did not expect a Bicep param when routing secrets to a secret store")
+		}
+
+		var found bool
+		for _, res := range result.Resources {
+			if res.Kind == KindSecretStore {
+				found = true
+				if !res.Synthesized {
+					t.Error("expected the secret store to be marked Synthesized")
+				}
+			}
+		}
+
+		if !found {
+			t.Error("expected a KindSecretStore entry in result.Resources")
+		}
+	})
+}
+
+func TestMapParameter(t *testing.T) {
+	t.Parallel()
+
+	newCtx := func() *translationContext {
+		return newTranslationContext(&AspireManifest{}, &translationConfig{})
+	}
+
+	t.Run("plain default", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := newCtx()
+		resource := ManifestResource{Type: "parameter.v0", Value: "production"}
+
+		mapParameter("environmentName", resource, ctx)
+
+		if len(ctx.parameters) != 1 {
+			t.Fatalf("expected 1 parameter, got %d", len(ctx.parameters))
+		}
+
+		param := ctx.parameters[0]
+		if param.Secure {
+			t.Error("expected a non-secure parameter")
+		}
+
+		if param.DefaultValue != "production" {
+			t.Errorf("expected DefaultValue %q, got %q", "production", param.DefaultValue)
+		}
+
+		if param.DefaultExpression != "" {
+			t.Errorf("expected no DefaultExpression, got %q", param.DefaultExpression)
+		}
+	})
+
+	t.Run("secure without generate", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := newCtx()
+		resource := ManifestResource{
+			Type:   "parameter.v0",
+			Value:  "generated-default",
+			Inputs: map[string]ManifestParamInput{"value": {Secret: true}},
+		}
+
+		mapParameter("dbPassword", resource, ctx)
+
+		if len(ctx.parameters) != 1 {
+			t.Fatalf("expected 1 parameter, got %d", len(ctx.parameters))
+		}
+
+		param := ctx.parameters[0]
+		if !param.Secure {
+			t.Error("expected a secure parameter")
+		}
+
+		if param.DefaultValue != "generated-default" {
+			t.Errorf("expected DefaultValue %q, got %q", "generated-default", param.DefaultValue)
+		}
+
+		if param.DefaultExpression != "" {
+			t.Errorf("expected no DefaultExpression, got %q", param.DefaultExpression)
+		}
+	})
+
+	t.Run("secure with generate", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := newCtx()
+		resource := ManifestResource{
+			Type:  "parameter.v0",
+			Value: "{dbPassword.inputs.value}",
+			Inputs: map[string]ManifestParamInput{
+				"value": {
+					Secret:  true,
+					Default: &ManifestParamDefault{Generate: &ManifestParamGenerate{MinLength: 22}},
+				},
+			},
+		}
+
+		mapParameter("dbPassword", resource, ctx)
+
+		if len(ctx.parameters) != 1 {
+			t.Fatalf("expected 1 parameter, got %d", len(ctx.parameters))
+		}
+
+		param := ctx.parameters[0]
+		if !param.Secure {
+			t.Error("expected a secure parameter")
+		}
+
+		if param.DefaultValue != "" {
+			t.Errorf("expected no literal DefaultValue, got %q", param.DefaultValue)
+		}
+
+		if param.DefaultExpression != "newGuid()" {
+			t.Errorf("expected DefaultExpression %q, got %q", "newGuid()", param.DefaultExpression)
+		}
+	})
+}
@@ -47,10 +47,50 @@ const (
 	// KindParameter represents parameter.v0 resources emitted as Bicep params.
 	KindParameter ResourceKind = "parameter"
 
+	// KindSecretStore represents a synthesized Applications.Core/secretStores resource
+	// backing one or more secret parameter.v0 resources.
+	KindSecretStore ResourceKind = "Applications.Core/secretStores"
+
+	// KindService represents a synthesized Applications.Core/services resource exposing a
+	// container's external bindings as NodePort-style port mappings. Only produced in
+	// GatewayModeNodePort, as an alternative to KindGateway.
+	KindService ResourceKind = "Applications.Core/services"
+
+	// KindExtender maps to Applications.Core/extenders, used to model managed Azure
+	// services (Aspire azure.* resource types) that have no dedicated Radius portable
+	// resource type. See azureManagedServiceHandler.
+	KindExtender ResourceKind = "Applications.Core/extenders"
+
+	// KindBuildOutput represents a resource marked ManifestResource.BuildOnly: a
+	// co-located companion that supplies a project.v0/v1 resource's image rather than
+	// becoming a standalone resource itself, inlined the same way KindValueResource is.
+	KindBuildOutput ResourceKind = "buildOutput"
+
 	// KindUnsupported represents unrecognized resource types that are skipped with a warning.
 	KindUnsupported ResourceKind = "unsupported"
 )
 
+// GatewayMode selects how synthesizeGateway exposes a container's external bindings.
+type GatewayMode string
+
+const (
+	// GatewayModeIngress synthesizes a single Applications.Core/gateways resource that
+	// routes to every external binding, mirroring a Kubernetes Ingress. This is the
+	// default.
+	GatewayModeIngress GatewayMode = "ingress"
+
+	// GatewayModeNodePort synthesizes one Applications.Core/services resource per
+	// container that has external bindings, exposing each as a NodePort-style port
+	// mapping instead of routing through a gateway, mirroring a Kubernetes Service of
+	// type NodePort.
+	GatewayModeNodePort GatewayMode = "nodeport"
+
+	// GatewayModeNone synthesizes neither a gateway nor services. Each external binding is
+	// instead recorded as a hostPort on its container's port spec, leaving it to the user
+	// to front the deployment themselves.
+	GatewayModeNone GatewayMode = "none"
+)
+
 // IsPortableResource returns true if the kind is a portable resource type.
 func (k ResourceKind) IsPortableResource() bool {
 	switch k {
@@ -61,11 +101,118 @@ func (k ResourceKind) IsPortableResource() bool {
 	}
 }
 
+// BuildMode controls how project.v0/v1 resources without an explicit ImageMappings
+// entry are turned into container images during translation.
+type BuildMode string
+
+const (
+	// BuildModeNone requires an explicit ImageMappings entry for every project resource.
+	// This is the default.
+	BuildModeNone BuildMode = "none"
+
+	// BuildModeBuildpacks builds project resources locally with Cloud Native Buildpacks
+	// (via the `pack` CLI) and uses the resulting image reference directly.
+	BuildModeBuildpacks BuildMode = "buildpacks"
+
+	// BuildModeTektonPipeline defers the build to a generated Tekton Pipeline instead of
+	// building locally. The emitted container image reference points at the image the
+	// pipeline will push once it runs.
+	BuildModeTektonPipeline BuildMode = "tekton-pipeline"
+
+	// BuildModeDotnetPublish builds project resources locally with the .NET SDK's built-in
+	// OCI image publishing (`dotnet publish /t:PublishContainer`) and uses the resulting
+	// image reference directly.
+	BuildModeDotnetPublish BuildMode = "dotnet-publish"
+
+	// BuildModePlaceholderParam skips building an image altogether and instead declares a
+	// Bicep parameter (named "<resource>Image") for the user to supply at deploy time, once
+	// the image has been built and pushed out of band.
+	BuildModePlaceholderParam BuildMode = "placeholder-param"
+
+	// BuildModeDockerfile builds project resources locally from a Dockerfile found in the
+	// project's source directory (via `docker build`), rather than the .NET SDK's built-in
+	// container publishing. Useful for project.v0/v1 resources that ship a custom Dockerfile.
+	BuildModeDockerfile BuildMode = "dockerfile"
+)
+
+// EmitFormat selects which backend Translate renders the translated resources to.
+type EmitFormat string
+
+const (
+	// FormatBicep renders the translated resources as a Radius Bicep file. This is the
+	// default.
+	FormatBicep EmitFormat = "bicep"
+
+	// FormatContainerAppYAML renders the translated resources as Azure Container Apps
+	// `containerapp` YAML manifests, one per Applications.Core/containers resource, for
+	// users targeting ACA directly without Radius resource providers.
+	FormatContainerAppYAML EmitFormat = "container-app-yaml"
+
+	// FormatKubernetesYAML renders the translated resources as plain Kubernetes
+	// Deployment/Service YAML manifests, one per Applications.Core/containers resource,
+	// for users deploying without Radius resource providers at all.
+	FormatKubernetesYAML EmitFormat = "kubernetes-yaml"
+
+	// FormatBicepAndContainerAppYAML renders both the Radius Bicep file and the Azure
+	// Container Apps YAML manifests from a single translation pass, for users who want to
+	// compare or migrate between the two without re-running Translate. Both
+	// TranslateResult.Bicep and TranslateResult.ContainerAppYAML are populated.
+	FormatBicepAndContainerAppYAML EmitFormat = "bicep-and-container-app-yaml"
+
+	// FormatHelm renders the translated resources as a Helm chart directory layout
+	// (Chart.yaml, values.yaml, templates/*.yaml), for users deploying with Helm instead of
+	// Radius resource providers or plain kubectl apply. Backing services map to Bitnami-style
+	// chart dependencies in Chart.yaml instead of FormatKubernetesYAML's env-var fallback.
+	// TranslateResult.HelmChart is populated; Bicep is left empty.
+	FormatHelm EmitFormat = "helm"
+)
+
+// SecretBackend controls how secret parameter.v0 resources (inputs.*.secret) are surfaced
+// in the generated Bicep.
+type SecretBackend string
+
+const (
+	// SecretBackendBicepParam emits a plain @secure() Bicep parameter that the user supplies
+	// at deploy time. This is the default.
+	SecretBackendBicepParam SecretBackend = "bicep-param"
+
+	// SecretBackendRadiusSecretStore synthesizes an Applications.Core/secretStores resource
+	// of type "generic" holding the secret values, and wires container env vars to it via
+	// valueFrom.secretRef.
+	SecretBackendRadiusSecretStore SecretBackend = "radius-secretstore"
+
+	// SecretBackendKubernetesSecret synthesizes an Applications.Core/secretStores resource
+	// that wraps an existing Kubernetes secret (populated out of band by the user) instead of
+	// declaring values inline.
+	SecretBackendKubernetesSecret SecretBackend = "kubernetes-secret"
+
+	// SecretBackendAzureKeyVault synthesizes an Applications.Core/secretStores resource
+	// backed by an Azure Key Vault instance (populated out of band by the user).
+	SecretBackendAzureKeyVault SecretBackend = "azure-keyvault"
+)
+
 // TranslateOptions configures the manifest-to-Bicep translation pipeline.
 type TranslateOptions struct {
-	// ManifestPath is the file path to the Aspire manifest JSON file.
+	// ManifestPath is the file path to the Aspire manifest JSON file. Ignored when
+	// ManifestPaths or WorkspacePath is set.
 	ManifestPath string
 
+	// ManifestPaths is a list of aspire-manifest.json file paths to translate together as
+	// a single Aspire app host workspace. Takes precedence over ManifestPath; ignored when
+	// WorkspacePath is set.
+	ManifestPaths []string
+
+	// WorkspacePath is a directory to scan recursively for aspire-manifest.json files, as
+	// an alternative to explicitly listing ManifestPaths. Takes precedence over both
+	// ManifestPath and ManifestPaths.
+	WorkspacePath string
+
+	// ComposePath is a Docker Compose file (e.g. docker-compose.yml) to translate instead
+	// of an Aspire manifest. Each Compose service is converted to a container.v0 resource
+	// and run through the same translation pipeline. Takes precedence over ManifestPath,
+	// ManifestPaths, and WorkspacePath.
+	ComposePath string
+
 	// AppName is the Radius application name. When set, the generated Bicep
 	// application resource uses this as its name (default: "app").
 	AppName string
@@ -75,12 +222,148 @@ type TranslateOptions struct {
 	EnvironmentName string
 
 	// ImageMappings maps project.v0/v1 resource names to container image references.
-	// Required for every project.v0/v1 resource in the manifest.
+	// Required for every project.v0/v1 resource in the manifest unless BuildMode is set.
 	ImageMappings map[string]string
 
 	// ResourceOverrides maps resource names to explicit Radius resource types,
 	// bypassing automatic backing-service detection.
 	ResourceOverrides map[string]ResourceKind
+
+	// BuildMode controls how project.v0/v1 resources without an ImageMappings entry
+	// are turned into images. Defaults to BuildModeNone.
+	BuildMode BuildMode
+
+	// Registry is the container registry that built images are tagged for. Used by
+	// BuildModeBuildpacks, BuildModeDockerfile, BuildModeDotnetPublish, and
+	// BuildModeTektonPipeline.
+	Registry string
+
+	// PushImages, when set, pushes images built locally (BuildModeBuildpacks,
+	// BuildModeDockerfile, BuildModeDotnetPublish) to Registry after a successful build.
+	PushImages bool
+
+	// BuilderImage overrides the default Cloud Native Buildpacks builder image used by
+	// BuildModeBuildpacks.
+	BuilderImage string
+
+	// SecretBackend controls how secret parameter.v0 resources are surfaced in the
+	// generated Bicep. Defaults to SecretBackendBicepParam.
+	SecretBackend SecretBackend
+
+	// SecretStoreName is the Radius name given to the synthesized secretStores resource.
+	// Only used when SecretBackend is not SecretBackendBicepParam. Defaults to "secrets".
+	SecretStoreName string
+
+	// RegistryResolver, when set, classifies container.v0/v1 resources by inspecting their
+	// remote image config (OCI manifest + config blob) instead of relying solely on
+	// backingServiceTable's name-prefix matching. Falls back to backingServiceTable when nil
+	// or when a lookup fails; the outcome is always recorded as a translation warning.
+	RegistryResolver RegistryResolver
+
+	// EmitFormat selects which backend renders the translated resources. Defaults to
+	// FormatBicep.
+	EmitFormat EmitFormat
+
+	// Env supplies the values available to "{env:VAR}" expression lookups in manifest
+	// fields. A lookup for a name not present here is treated as unset, which resolves to
+	// the expression's default (if any) or a translation error otherwise.
+	Env map[string]string
+
+	// GatewayMode selects how a container's external bindings are exposed: GatewayModeIngress
+	// (default) synthesizes a gateway, GatewayModeNodePort synthesizes per-container
+	// services, and GatewayModeNone annotates containers with hostPort instead of
+	// synthesizing either.
+	GatewayMode GatewayMode
+
+	// TemplateDir, when set, is a directory containing text/template files that override
+	// the Bicep emitter's built-in templates on a per-resource-kind basis (e.g.
+	// "container.tmpl", "redis.tmpl", "gateway.tmpl"). A kind with no matching file in
+	// TemplateDir keeps using its built-in template. Only consulted when EmitFormat is
+	// FormatBicep (or unset) or FormatBicepAndContainerAppYAML.
+	TemplateDir string
+
+	// StrictSchema upgrades schema-validation problems that are normally only warnings
+	// into hard translation errors: a manifest whose "$schema" is missing or unrecognized
+	// by validateManifestSchema fails translation instead of falling back to permissive
+	// mode. It has no effect on violations against a recognized schema, which are always
+	// hard errors regardless of this setting.
+	StrictSchema bool
+
+	// Mode selects whether Translate generates fresh output (ModeGenerate, the default)
+	// or instead reports drift against ExistingOutputDir (ModeDrift).
+	Mode Mode
+
+	// ExistingOutputDir is the directory containing a previous translation's output
+	// (app.bicep), compared against the freshly regenerated Bicep when Mode is
+	// ModeDrift. Ignored otherwise.
+	ExistingOutputDir string
+
+	// GatewayAPI selects which resource FormatKubernetesYAML and FormatHelm use to expose
+	// gateway routes: false (default) renders a networking.k8s.io Ingress; true renders a
+	// Gateway API HTTPRoute instead. Has no effect on EmitFormat values that don't render
+	// Kubernetes-native gateway objects.
+	GatewayAPI bool
+
+	// ManifestOverlays maps an Aspire container resource name to a YAML or JSON snippet
+	// (see ContainerOverlay) hand-authored outside the Aspire manifest. Each overlay is
+	// merged into the resource's translated ContainerSpec before expression resolution, so
+	// overlay env values referencing other resources (e.g. "{cache.connectionString}") get
+	// the same Bicep interpolation as manifest-native env vars. A field an overlay sets that
+	// the manifest also set is a conflict: the overlay wins and a warning is recorded.
+	ManifestOverlays map[string]string
+}
+
+// Mode selects whether Translate produces output for a fresh deployment or reports how a
+// regenerated manifest would differ from output already on disk.
+type Mode string
+
+const (
+	// ModeGenerate is the default: Translate behaves exactly as it did before Mode
+	// existed, leaving TranslateResult.Drift empty.
+	ModeGenerate Mode = "generate"
+
+	// ModeDrift runs the full translation pipeline but, instead of expecting the caller
+	// to overwrite ExistingOutputDir, populates TranslateResult.Drift with how the
+	// regenerated Bicep differs from what's already there.
+	ModeDrift Mode = "drift"
+)
+
+// DriftKind classifies a single DriftEntry.
+type DriftKind string
+
+const (
+	// DriftAdded marks a file or resource block present in the regenerated output with no
+	// counterpart in ExistingOutputDir.
+	DriftAdded DriftKind = "Added"
+
+	// DriftRemoved marks a file or resource block present in ExistingOutputDir with no
+	// counterpart in the regenerated output.
+	DriftRemoved DriftKind = "Removed"
+
+	// DriftModified marks a file or resource block present on both sides with differing
+	// content.
+	DriftModified DriftKind = "Modified"
+)
+
+// DriftEntry describes one difference found by detectDrift between the freshly rendered
+// Bicep and what's already on disk under TranslateOptions.ExistingOutputDir.
+type DriftEntry struct {
+	// Path is the output file this entry concerns, relative to ExistingOutputDir (e.g.
+	// "app.bicep").
+	Path string
+
+	// Kind classifies the difference.
+	Kind DriftKind
+
+	// UnifiedDiff is a unified-format diff of the old content against the new. Empty for
+	// a whole-file DriftAdded entry, since there's no prior content to diff against.
+	UnifiedDiff string
+
+	// ResourceName is the original Aspire resource name this entry's Bicep identifier
+	// resolves to via ctx.resources, resolved by detectDrift. Empty for the whole-file
+	// entry and for blocks (e.g. the application resource, parameters) with no
+	// corresponding manifest resource.
+	ResourceName string
 }
 
 // TranslateResult contains the output of a successful translation.
@@ -93,6 +376,43 @@ type TranslateResult struct {
 
 	// Warnings is a list of non-fatal warning messages produced during translation.
 	Warnings []string
+
+	// Pipeline is the generated Tekton Pipeline YAML. Set only when BuildMode is
+	// BuildModeTektonPipeline and at least one project resource required a build.
+	Pipeline string
+
+	// Modules groups the translated resources by their source manifest file. Populated
+	// only for multi-manifest workspaces (see TranslateOptions.ManifestPaths/WorkspacePath).
+	Modules []TranslatedModule
+
+	// ContainerAppYAML maps filename to rendered Azure Container Apps YAML content. Set
+	// when TranslateOptions.EmitFormat is FormatContainerAppYAML (Bicep is left empty) or
+	// FormatBicepAndContainerAppYAML (Bicep is also populated).
+	ContainerAppYAML map[string]string
+
+	// KubernetesYAML maps filename to rendered Kubernetes Deployment/Service YAML content.
+	// Set only when TranslateOptions.EmitFormat is FormatKubernetesYAML, in which case
+	// Bicep is left empty.
+	KubernetesYAML map[string]string
+
+	// Drift lists the differences between the regenerated Bicep and what's already on
+	// disk under TranslateOptions.ExistingOutputDir. Set only when Mode is ModeDrift.
+	Drift []DriftEntry
+
+	// HelmChart maps a Helm chart file path (e.g. "Chart.yaml", "values.yaml",
+	// "templates/api-deployment.yaml") to its rendered content. Set only when
+	// TranslateOptions.EmitFormat is FormatHelm, in which case Bicep is left empty.
+	HelmChart map[string]string
+}
+
+// TranslatedModule groups translated resources by the Aspire manifest file they came from,
+// for workspaces made up of more than one aspire-manifest.json.
+type TranslatedModule struct {
+	// ManifestPath is the source manifest file this module was generated from.
+	ManifestPath string
+
+	// Resources lists the original Aspire resource names sourced from this manifest.
+	Resources []string
 }
 
 // TranslatedResource describes a single resource in the translation output.
@@ -108,4 +428,12 @@ type TranslatedResource struct {
 
 	// Synthesized is true if this resource was auto-generated (e.g., gateway, application).
 	Synthesized bool
+
+	// BuiltImage is the image reference produced by an automatic build (BuildModeBuildpacks,
+	// BuildModeTektonPipeline, BuildModeDotnetPublish or BuildModeDockerfile), if any.
+	BuiltImage string
+
+	// Mode is the GatewayMode that produced this resource. Only set on the gateway or
+	// service summaries synthesized for external bindings; empty otherwise.
+	Mode GatewayMode
 }
@@ -0,0 +1,227 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// manifestFileName is the conventional file name Aspire's AppHost publishes manifests as.
+const manifestFileName = "aspire-manifest.json"
+
+// resolveManifestPaths determines which manifest files a Translate call should read,
+// honoring WorkspacePath, ManifestPaths, and ManifestPath in that order of precedence.
+func resolveManifestPaths(opts TranslateOptions) ([]string, error) {
+	if opts.WorkspacePath != "" {
+		return discoverWorkspaceManifests(opts.WorkspacePath)
+	}
+
+	if len(opts.ManifestPaths) > 0 {
+		return opts.ManifestPaths, nil
+	}
+
+	if opts.ManifestPath == "" {
+		return nil, fmt.Errorf("no manifest path provided")
+	}
+
+	return []string{opts.ManifestPath}, nil
+}
+
+// discoverWorkspaceManifests walks workspacePath and returns every aspire-manifest.json
+// found, sorted for deterministic module ordering.
+func discoverWorkspaceManifests(workspacePath string) ([]string, error) {
+	var paths []string
+
+	err := filepath.Walk(workspacePath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() && filepath.Base(path) == manifestFileName {
+			paths = append(paths, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan workspace %q: %w", workspacePath, err)
+	}
+
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no %s files found under workspace %q", manifestFileName, workspacePath)
+	}
+
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+// loadManifests parses the manifest(s) at paths and returns a single combined
+// AspireManifest along with a map from resource name to source manifest path.
+func loadManifests(paths []string) (*AspireManifest, map[string]string, error) {
+	if len(paths) == 1 {
+		manifest, err := parseManifest(paths[0])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		origin := make(map[string]string, len(manifest.Resources))
+		for name := range manifest.Resources {
+			origin[name] = paths[0]
+		}
+
+		return manifest, origin, nil
+	}
+
+	return mergeManifests(paths)
+}
+
+// mergeManifests parses every manifest at paths and merges their resources into a single
+// AspireManifest so the rest of the translation pipeline can run over the whole Aspire
+// app host workspace as if it were one manifest. Resource names that collide across
+// manifests are namespaced as "<module>_<name>", where module is derived from the
+// manifest's parent directory name; expression references to a renamed resource from
+// within its own manifest are rewritten to match.
+func mergeManifests(paths []string) (*AspireManifest, map[string]string, error) {
+	type loadedManifest struct {
+		path     string
+		module   string
+		manifest *AspireManifest
+	}
+
+	loaded := make([]loadedManifest, 0, len(paths))
+	owners := make(map[string][]string) // original resource name -> manifest paths defining it
+
+	for _, path := range paths {
+		manifest, err := parseManifest(path)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		loaded = append(loaded, loadedManifest{path: path, module: manifestModuleName(path), manifest: manifest})
+
+		for name := range manifest.Resources {
+			owners[name] = append(owners[name], path)
+		}
+	}
+
+	merged := &AspireManifest{Resources: make(map[string]ManifestResource)}
+	origin := make(map[string]string)
+
+	for _, lm := range loaded {
+		merged.Warnings = append(merged.Warnings, lm.manifest.Warnings...)
+
+		// Resources that collide with a same-named resource in another manifest are
+		// namespaced with this manifest's module prefix.
+		rename := make(map[string]string)
+		for name := range lm.manifest.Resources {
+			if len(owners[name]) > 1 {
+				rename[name] = lm.module + "_" + name
+			}
+		}
+
+		for name, resource := range lm.manifest.Resources {
+			resource = rewriteSelfReferences(resource, rename)
+
+			finalName := name
+			if renamed, ok := rename[name]; ok {
+				finalName = renamed
+			}
+
+			if _, exists := merged.Resources[finalName]; exists {
+				return nil, nil, fmt.Errorf("resource %q from %s collides with a resource of the same name from another manifest", finalName, lm.path)
+			}
+
+			merged.Resources[finalName] = resource
+			origin[finalName] = lm.path
+		}
+	}
+
+	return merged, origin, nil
+}
+
+// rewriteSelfReferences rewrites {name.property.path} expression references within a
+// resource's Env and ConnectionString fields so they follow a sibling resource that was
+// renamed due to a cross-manifest collision. Resources outside rename are left untouched.
+func rewriteSelfReferences(resource ManifestResource, rename map[string]string) ManifestResource {
+	if len(rename) == 0 {
+		return resource
+	}
+
+	rewrite := func(s string) string {
+		for original, renamed := range rename {
+			s = strings.ReplaceAll(s, "{"+original+".", "{"+renamed+".")
+		}
+
+		return s
+	}
+
+	if resource.ConnectionString != "" {
+		resource.ConnectionString = rewrite(resource.ConnectionString)
+	}
+
+	if len(resource.Env) > 0 {
+		env := make(map[string]string, len(resource.Env))
+		for key, value := range resource.Env {
+			env[key] = rewrite(value)
+		}
+
+		resource.Env = env
+	}
+
+	return resource
+}
+
+// manifestModuleName derives a Bicep-safe module name from a manifest's parent directory
+// name (falling back to the file name itself if the manifest sits at the workspace root).
+func manifestModuleName(path string) string {
+	dir := filepath.Base(filepath.Dir(path))
+	if dir == "." || dir == string(filepath.Separator) || dir == "" {
+		dir = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	return sanitize(dir)
+}
+
+// buildModulesFromOrigin groups resource names by their source manifest path, sorted for
+// deterministic output.
+func buildModulesFromOrigin(origin map[string]string) []TranslatedModule {
+	grouped := make(map[string][]string)
+	for name, path := range origin {
+		grouped[path] = append(grouped[path], name)
+	}
+
+	paths := make([]string, 0, len(grouped))
+	for path := range grouped {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	modules := make([]TranslatedModule, 0, len(paths))
+	for _, path := range paths {
+		names := grouped[path]
+		sort.Strings(names)
+		modules = append(modules, TranslatedModule{ManifestPath: path, Resources: names})
+	}
+
+	return modules
+}
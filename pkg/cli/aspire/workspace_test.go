@@ -0,0 +1,201 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aspire
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeManifest(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+}
+
+func TestResolveManifestPaths(t *testing.T) {
+	t.Parallel()
+
+	t.Run("manifest path", func(t *testing.T) {
+		t.Parallel()
+
+		paths, err := resolveManifestPaths(TranslateOptions{ManifestPath: "a.json"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(paths) != 1 || paths[0] != "a.json" {
+			t.Errorf("unexpected paths: %v", paths)
+		}
+	})
+
+	t.Run("manifest paths take precedence", func(t *testing.T) {
+		t.Parallel()
+
+		paths, err := resolveManifestPaths(TranslateOptions{ManifestPath: "a.json", ManifestPaths: []string{"b.json", "c.json"}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(paths) != 2 || paths[0] != "b.json" || paths[1] != "c.json" {
+			t.Errorf("unexpected paths: %v", paths)
+		}
+	})
+
+	t.Run("no manifest provided", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := resolveManifestPaths(TranslateOptions{})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestDiscoverWorkspaceManifests(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeManifest(t, filepath.Join(dir, "api", manifestFileName), `{"resources":{}}`)
+	writeManifest(t, filepath.Join(dir, "worker", manifestFileName), `{"resources":{}}`)
+	writeManifest(t, filepath.Join(dir, "worker", "notes.json"), `{}`)
+
+	paths, err := discoverWorkspaceManifests(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(paths) != 2 {
+		t.Fatalf("expected 2 manifests, got %d: %v", len(paths), paths)
+	}
+}
+
+func TestDiscoverWorkspaceManifests_Empty(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	if _, err := discoverWorkspaceManifests(dir); err == nil {
+		t.Fatal("expected error for workspace with no manifests")
+	}
+}
+
+func TestMergeManifests(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	apiPath := filepath.Join(dir, "api", manifestFileName)
+	workerPath := filepath.Join(dir, "worker", manifestFileName)
+
+	writeManifest(t, apiPath, `{
+		"resources": {
+			"cache": {"type": "redis.server.v0"},
+			"api": {"type": "project.v0", "env": {"CACHE_URL": "{cache.connectionString}"}}
+		}
+	}`)
+	writeManifest(t, workerPath, `{
+		"resources": {
+			"cache": {"type": "redis.server.v0"},
+			"worker": {"type": "project.v0", "env": {"CACHE_URL": "{cache.connectionString}"}}
+		}
+	}`)
+
+	manifest, origin, err := mergeManifests([]string{apiPath, workerPath})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	apiModule := manifestModuleName(apiPath)
+	workerModule := manifestModuleName(workerPath)
+
+	apiCacheName := apiModule + "_cache"
+	workerCacheName := workerModule + "_cache"
+
+	if _, ok := manifest.Resources[apiCacheName]; !ok {
+		t.Fatalf("expected renamed resource %q, got %v", apiCacheName, manifest.Resources)
+	}
+
+	if _, ok := manifest.Resources[workerCacheName]; !ok {
+		t.Fatalf("expected renamed resource %q, got %v", workerCacheName, manifest.Resources)
+	}
+
+	// Uniquely-named resources keep their original names.
+	apiResource, ok := manifest.Resources["api"]
+	if !ok {
+		t.Fatalf("expected resource \"api\" to keep its original name, got %v", manifest.Resources)
+	}
+
+	if apiResource.Env["CACHE_URL"] != "{"+apiCacheName+".connectionString}" {
+		t.Errorf("expected self-reference to be rewritten, got %q", apiResource.Env["CACHE_URL"])
+	}
+
+	if origin["api"] != apiPath {
+		t.Errorf("unexpected origin for \"api\": %q", origin["api"])
+	}
+
+	if origin[apiCacheName] != apiPath || origin[workerCacheName] != workerPath {
+		t.Errorf("unexpected origin mapping: %v", origin)
+	}
+}
+
+func TestSanitizeAllForWorkspace(t *testing.T) {
+	t.Parallel()
+
+	t.Run("single manifest falls back to sanitizeAll", func(t *testing.T) {
+		t.Parallel()
+
+		origin := map[string]string{"api": "a/aspire-manifest.json", "cache": "a/aspire-manifest.json"}
+
+		result, err := sanitizeAllForWorkspace([]string{"api", "cache"}, origin)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if result["api"] != "api" || result["cache"] != "cache" {
+			t.Errorf("unexpected mapping: %v", result)
+		}
+	})
+
+	t.Run("cross-manifest collision is namespaced", func(t *testing.T) {
+		t.Parallel()
+
+		// "api-cache" and "api_cache" are distinct resource names but sanitize to the same
+		// Bicep identifier; when they come from different manifests that's only a soft
+		// collision, resolved by namespacing rather than failing outright.
+		origin := map[string]string{
+			"api-cache": "api/aspire-manifest.json",
+			"api_cache": "worker/aspire-manifest.json",
+		}
+
+		names := []string{"api-cache", "api_cache"}
+		result, err := sanitizeAllForWorkspace(names, origin)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if result["api-cache"] == result["api_cache"] {
+			t.Errorf("expected distinct identifiers, got %v", result)
+		}
+	})
+}
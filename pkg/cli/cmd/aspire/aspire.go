@@ -21,14 +21,18 @@ import (
 )
 
 // NewCommand creates a new cobra.Command for the `rad aspire` command group.
+//
+// There are currently no subcommands registered here: Aspire manifest translation is
+// exposed through `rad init --from-aspire-manifest` (see pkg/cli/cmd/radinit/aspire.go)
+// rather than a standalone `rad aspire convert` command, so that translating a manifest
+// and initializing a Radius environment/application from it happen in one step. This
+// command group is kept as a placeholder in case Aspire-specific subcommands (unrelated
+// to `rad init`) are needed later.
 func NewCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "aspire",
 		Short: "Manage Aspire-related tasks for Radius",
-		Long:  `Manage Aspire-related tasks for Radius. Use subcommands to convert Aspire manifests to Radius Bicep files.`,
-		Example: `
-# Convert an Aspire manifest to a Radius Bicep file
-rad aspire convert aspire-manifest.json`,
+		Long:  `Manage Aspire-related tasks for Radius. Aspire manifest translation is available via "rad init --from-aspire-manifest".`,
 	}
 
 	return cmd
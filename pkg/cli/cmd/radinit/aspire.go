@@ -29,12 +29,38 @@ import (
 // runAspireTranslation runs the manifest translation pipeline when --from-aspire-manifest is set.
 // It skips the interactive init prompts and performs only the manifest translation.
 func (r *Runner) runAspireTranslation(ctx context.Context) error {
+	imageMappings, err := r.loadAspireImageMappings()
+	if err != nil {
+		return err
+	}
+
+	manifestOverlays, err := r.aspireManifestOverlays()
+	if err != nil {
+		return err
+	}
+
+	mode := aspire.ModeGenerate
+	if r.AspireExistingOutputDir != "" {
+		mode = aspire.ModeDrift
+	}
+
 	opts := aspire.TranslateOptions{
 		ManifestPath:      r.AspireManifestPath,
+		WorkspacePath:     r.AspireWorkspacePath,
 		AppName:           r.AspireAppName,
 		EnvironmentName:   r.AspireEnvironment,
-		ImageMappings:     r.aspireImageMappings(),
+		ImageMappings:     imageMappings,
 		ResourceOverrides: r.aspireResourceOverrides(),
+		BuildMode:         aspire.BuildMode(r.AspireBuildMode),
+		Registry:          r.AspireRegistry,
+		BuilderImage:      r.AspireBuilderImage,
+		SecretBackend:     aspire.SecretBackend(r.AspireSecretBackend),
+		SecretStoreName:   r.AspireSecretStoreName,
+		EmitFormat:        aspire.EmitFormat(r.AspireEmitFormat),
+		GatewayAPI:        r.AspireGatewayAPI,
+		Mode:              mode,
+		ExistingOutputDir: r.AspireExistingOutputDir,
+		ManifestOverlays:  manifestOverlays,
 	}
 
 	result, err := aspire.Translate(opts)
@@ -74,11 +100,55 @@ func (r *Runner) runAspireTranslation(ctx context.Context) error {
 			label += " (recipe)"
 		}
 
+		if res.BuiltImage != "" {
+			label += fmt.Sprintf(" (built: %s)", res.BuiltImage)
+		}
+
 		r.Output.LogInfo("  - %s → %s", res.OriginalName, label)
 	}
 
+	if len(result.Modules) > 0 {
+		r.Output.LogInfo("")
+		r.Output.LogInfo("From %d manifests in the workspace:", len(result.Modules))
+		for _, module := range result.Modules {
+			r.Output.LogInfo("  - %s: %s", module.ManifestPath, strings.Join(module.Resources, ", "))
+		}
+	}
+
 	r.Output.LogInfo("")
 	r.Output.LogInfo("Generated: %s", outputPath)
+
+	// Write the companion Tekton pipeline when the translation produced one.
+	if result.Pipeline != "" {
+		pipelinePath := filepath.Join(r.AspireOutputDir, "pipeline.yaml")
+		if err := os.WriteFile(pipelinePath, []byte(result.Pipeline), 0644); err != nil {
+			return fmt.Errorf("failed to write pipeline.yaml: %w", err)
+		}
+
+		r.Output.LogInfo("Generated: %s", pipelinePath)
+	}
+
+	// Print secret population instructions when a non-default secret backend was used.
+	if r.AspireSecretBackend != "" && r.AspireSecretBackend != string(aspire.SecretBackendBicepParam) {
+		storeName := r.AspireSecretStoreName
+		if storeName == "" {
+			storeName = "secrets"
+		}
+
+		r.Output.LogInfo("")
+
+		switch aspire.SecretBackend(r.AspireSecretBackend) {
+		case aspire.SecretBackendRadiusSecretStore:
+			r.Output.LogInfo("After deploy, set any secret values not already populated with:")
+			r.Output.LogInfo("  rad resource update Applications.Core/secretStores %s -p data.<key>.value=<value>", storeName)
+		case aspire.SecretBackendKubernetesSecret:
+			r.Output.LogInfo("Before deploy, create the Kubernetes secret %s in the default namespace with the required keys.", storeName)
+		case aspire.SecretBackendAzureKeyVault:
+			r.Output.LogInfo("Before deploy, edit app.bicep and replace <YOUR_KEYVAULT_RESOURCE_ID> with your Azure Key Vault resource ID,")
+			r.Output.LogInfo("and ensure it contains the required secret keys.")
+		}
+	}
+
 	r.Output.LogInfo("")
 	r.Output.LogInfo("Deploy with: rad deploy %s -p environment=<your-env-id> -p application=<your-app-id>", outputPath)
 
@@ -102,6 +172,36 @@ func (r *Runner) aspireImageMappings() map[string]string {
 	return result
 }
 
+// loadAspireImageMappings merges image mappings read from --image-map (if set) with
+// --image-mapping flag overrides, which take precedence over the file.
+func (r *Runner) loadAspireImageMappings() (map[string]string, error) {
+	result, err := r.aspireImageMapFile()
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := r.aspireImageMappings()
+	for name, image := range overrides {
+		if result == nil {
+			result = make(map[string]string, len(overrides))
+		}
+
+		result[name] = image
+	}
+
+	return result, nil
+}
+
+// aspireImageMapFile reads --image-map, a JSON file mapping project resource names to
+// container image references, returning nil if no file was specified.
+func (r *Runner) aspireImageMapFile() (map[string]string, error) {
+	if r.AspireImageMapFile == "" {
+		return nil, nil
+	}
+
+	return aspire.LoadImageMappings(r.AspireImageMapFile)
+}
+
 // aspireResourceOverrides parses --resource-override flags into a map.
 func (r *Runner) aspireResourceOverrides() map[string]aspire.ResourceKind {
 	if len(r.AspireResourceOverrides) == 0 {
@@ -119,7 +219,32 @@ func (r *Runner) aspireResourceOverrides() map[string]aspire.ResourceKind {
 	return result
 }
 
-// isAspireMode returns true if the user specified --from-aspire-manifest.
+// aspireManifestOverlays reads the files referenced by --overlay flags into a map from
+// Aspire resource name to overlay snippet content.
+func (r *Runner) aspireManifestOverlays() (map[string]string, error) {
+	if len(r.AspireOverlays) == 0 {
+		return nil, nil
+	}
+
+	result := make(map[string]string, len(r.AspireOverlays))
+	for _, overlay := range r.AspireOverlays {
+		name, path, ok := strings.Cut(overlay, "=")
+		if !ok {
+			continue
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read overlay file %q for resource %q: %w", path, name, err)
+		}
+
+		result[name] = string(content)
+	}
+
+	return result, nil
+}
+
+// isAspireMode returns true if the user specified --from-aspire-manifest or --from-aspire-workspace.
 func (r *Runner) isAspireMode() bool {
-	return r.AspireManifestPath != ""
+	return r.AspireManifestPath != "" || r.AspireWorkspacePath != ""
 }
@@ -0,0 +1,40 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package radinit
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/radius-project/radius/pkg/cli/aspire"
+)
+
+// bindAspireFlags registers the --from-aspire-* flags that control manifest translation
+// onto cmd, binding each one to its corresponding Runner field. It's called from rad
+// init's flag setup alongside --from-aspire-manifest, --image-mapping, --image-map, and
+// --resource-override, which predate this function.
+func (r *Runner) bindAspireFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&r.AspireBuildMode, "build-mode", string(aspire.BuildModeNone), "how project.v0/v1 resources without an image mapping are built into images (none, buildpacks, tekton-pipeline, dotnet-publish, placeholder-param, dockerfile)")
+	cmd.Flags().StringVar(&r.AspireRegistry, "registry", "", "container registry that built images are tagged for")
+	cmd.Flags().StringVar(&r.AspireBuilderImage, "builder-image", "", "Cloud Native Buildpacks builder image to use with --build-mode=buildpacks")
+	cmd.Flags().StringVar(&r.AspireWorkspacePath, "from-aspire-workspace", "", "path to a directory of Aspire app host manifests to translate, as an alternative to --from-aspire-manifest")
+	cmd.Flags().StringVar(&r.AspireSecretBackend, "secret-backend", string(aspire.SecretBackendBicepParam), "how secret parameter.v0 resources are surfaced (bicep-param, radius-secretstore, kubernetes-secret, azure-keyvault)")
+	cmd.Flags().StringVar(&r.AspireSecretStoreName, "secret-store-name", "", "name given to the synthesized secretStores resource, used when --secret-backend is not bicep-param")
+	cmd.Flags().StringVar(&r.AspireEmitFormat, "emit-format", string(aspire.FormatBicep), "backend to render the translated resources to (bicep, container-app-yaml, kubernetes-yaml, bicep-and-container-app-yaml, helm)")
+	cmd.Flags().BoolVar(&r.AspireGatewayAPI, "gateway-api", false, "use a Gateway API HTTPRoute instead of a networking.k8s.io Ingress for --emit-format=kubernetes-yaml or helm")
+	cmd.Flags().StringVar(&r.AspireExistingOutputDir, "existing-output-dir", "", "directory containing a previous translation's output; translation runs in drift mode against it, but the drift result is not printed and app.bicep is still written to the output directory")
+	cmd.Flags().StringArrayVar(&r.AspireOverlays, "overlay", nil, "name=path mapping an Aspire container resource to a YAML or JSON overlay snippet file, merged into its translated container; may be repeated")
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2023 The Radius Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package radinit
+
+import (
+	"testing"
+
+	"github.com/radius-project/radius/pkg/cli/aspire"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_bindAspireFlags_BuildMode(t *testing.T) {
+	t.Parallel()
+
+	r := &Runner{}
+	cmd := &cobra.Command{Use: "init"}
+	r.bindAspireFlags(cmd)
+
+	// Defaults.
+	assert.Equal(t, string(aspire.BuildModeNone), r.AspireBuildMode)
+	assert.Equal(t, "", r.AspireRegistry)
+	assert.Equal(t, "", r.AspireBuilderImage)
+
+	require.NoError(t, cmd.Flags().Parse([]string{
+		"--build-mode", "buildpacks",
+		"--registry", "myregistry.io",
+		"--builder-image", "paketobuildpacks/builder-jammy-base",
+	}))
+
+	assert.Equal(t, "buildpacks", r.AspireBuildMode)
+	assert.Equal(t, "myregistry.io", r.AspireRegistry)
+	assert.Equal(t, "paketobuildpacks/builder-jammy-base", r.AspireBuilderImage)
+}
+
+func Test_bindAspireFlags_WorkspacePath(t *testing.T) {
+	t.Parallel()
+
+	r := &Runner{}
+	cmd := &cobra.Command{Use: "init"}
+	r.bindAspireFlags(cmd)
+
+	assert.Equal(t, "", r.AspireWorkspacePath)
+
+	require.NoError(t, cmd.Flags().Parse([]string{"--from-aspire-workspace", "./apphost"}))
+
+	assert.Equal(t, "./apphost", r.AspireWorkspacePath)
+}
+
+func Test_bindAspireFlags_SecretBackend(t *testing.T) {
+	t.Parallel()
+
+	r := &Runner{}
+	cmd := &cobra.Command{Use: "init"}
+	r.bindAspireFlags(cmd)
+
+	assert.Equal(t, string(aspire.SecretBackendBicepParam), r.AspireSecretBackend)
+	assert.Equal(t, "", r.AspireSecretStoreName)
+
+	require.NoError(t, cmd.Flags().Parse([]string{
+		"--secret-backend", "radius-secretstore",
+		"--secret-store-name", "app-secrets",
+	}))
+
+	assert.Equal(t, "radius-secretstore", r.AspireSecretBackend)
+	assert.Equal(t, "app-secrets", r.AspireSecretStoreName)
+}
+
+func Test_bindAspireFlags_EmitAndOverlay(t *testing.T) {
+	t.Parallel()
+
+	r := &Runner{}
+	cmd := &cobra.Command{Use: "init"}
+	r.bindAspireFlags(cmd)
+
+	assert.Equal(t, string(aspire.FormatBicep), r.AspireEmitFormat)
+	assert.False(t, r.AspireGatewayAPI)
+	assert.Equal(t, "", r.AspireExistingOutputDir)
+	assert.Empty(t, r.AspireOverlays)
+
+	require.NoError(t, cmd.Flags().Parse([]string{
+		"--emit-format", "kubernetes-yaml",
+		"--gateway-api",
+		"--existing-output-dir", "./prev-output",
+		"--overlay", "api=./overlays/api.yaml",
+		"--overlay", "worker=./overlays/worker.yaml",
+	}))
+
+	assert.Equal(t, "kubernetes-yaml", r.AspireEmitFormat)
+	assert.True(t, r.AspireGatewayAPI)
+	assert.Equal(t, "./prev-output", r.AspireExistingOutputDir)
+	assert.Equal(t, []string{"api=./overlays/api.yaml", "worker=./overlays/worker.yaml"}, r.AspireOverlays)
+}
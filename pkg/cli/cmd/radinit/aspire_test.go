@@ -71,6 +71,79 @@ func Test_aspireImageMappings(t *testing.T) {
 	})
 }
 
+func Test_loadAspireImageMappings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no file or flags", func(t *testing.T) {
+		t.Parallel()
+
+		r := &Runner{}
+		result, err := r.loadAspireImageMappings()
+		require.NoError(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("flag overrides take precedence over the file", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "image-map.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"api": "myregistry.io/api:v1", "worker": "myregistry.io/worker:v1"}`), 0644))
+
+		r := &Runner{
+			AspireImageMapFile:  path,
+			AspireImageMappings: []string{"api=myregistry.io/api:v2"},
+		}
+
+		result, err := r.loadAspireImageMappings()
+		require.NoError(t, err)
+		assert.Equal(t, "myregistry.io/api:v2", result["api"])
+		assert.Equal(t, "myregistry.io/worker:v1", result["worker"])
+	})
+
+	t.Run("propagates an error reading the file", func(t *testing.T) {
+		t.Parallel()
+
+		r := &Runner{AspireImageMapFile: filepath.Join(t.TempDir(), "missing.json")}
+		_, err := r.loadAspireImageMappings()
+		assert.Error(t, err)
+	})
+}
+
+func Test_aspireManifestOverlays(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no overlays", func(t *testing.T) {
+		t.Parallel()
+
+		r := &Runner{}
+		result, err := r.aspireManifestOverlays()
+		require.NoError(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("reads each overlay file keyed by resource name", func(t *testing.T) {
+		t.Parallel()
+
+		tmpDir := t.TempDir()
+		apiOverlay := filepath.Join(tmpDir, "api.yaml")
+		require.NoError(t, os.WriteFile(apiOverlay, []byte("env:\n  LOG_LEVEL: debug\n"), 0644))
+
+		r := &Runner{AspireOverlays: []string{"api=" + apiOverlay}}
+
+		result, err := r.aspireManifestOverlays()
+		require.NoError(t, err)
+		assert.Equal(t, "env:\n  LOG_LEVEL: debug\n", result["api"])
+	})
+
+	t.Run("propagates an error reading the file", func(t *testing.T) {
+		t.Parallel()
+
+		r := &Runner{AspireOverlays: []string{"api=" + filepath.Join(t.TempDir(), "missing.yaml")}}
+		_, err := r.aspireManifestOverlays()
+		assert.Error(t, err)
+	})
+}
+
 func Test_aspireResourceOverrides(t *testing.T) {
 	t.Parallel()
 
@@ -144,6 +217,53 @@ func Test_runAspireTranslation(t *testing.T) {
 	assert.Contains(t, bicep, "param environment string = 'testenv'")
 }
 
+func Test_runAspireTranslation_Workspace(t *testing.T) {
+	t.Parallel()
+
+	// Create a workspace with two manifests that both define a "cache" resource.
+	tmpDir := t.TempDir()
+
+	apiDir := filepath.Join(tmpDir, "api")
+	require.NoError(t, os.MkdirAll(apiDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(apiDir, "aspire-manifest.json"), []byte(`{
+		"resources": {
+			"cache": {"type": "redis.server.v0"},
+			"api": {"type": "container.v0", "image": "myapp/api:latest"}
+		}
+	}`), 0644))
+
+	workerDir := filepath.Join(tmpDir, "worker")
+	require.NoError(t, os.MkdirAll(workerDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(workerDir, "aspire-manifest.json"), []byte(`{
+		"resources": {
+			"cache": {"type": "redis.server.v0"},
+			"worker": {"type": "container.v0", "image": "myapp/worker:latest"}
+		}
+	}`), 0644))
+
+	outputDir := filepath.Join(tmpDir, "output")
+
+	r := &Runner{
+		Output:              &output.MockOutput{},
+		AspireWorkspacePath: tmpDir,
+		AspireAppName:       "testapp",
+		AspireOutputDir:     outputDir,
+	}
+
+	require.True(t, r.isAspireMode())
+
+	err := r.runAspireTranslation(context.Background())
+	require.NoError(t, err)
+
+	bicepPath := filepath.Join(outputDir, "app.bicep")
+	data, err := os.ReadFile(bicepPath)
+	require.NoError(t, err)
+
+	bicep := string(data)
+	assert.Contains(t, bicep, "api")
+	assert.Contains(t, bicep, "worker")
+}
+
 func Test_runAspireTranslation_Error(t *testing.T) {
 	t.Parallel()
 
@@ -157,3 +277,46 @@ func Test_runAspireTranslation_Error(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "manifest file not found")
 }
+
+func Test_runAspireTranslation_SecretBackend(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	manifestPath := filepath.Join(tmpDir, "manifest.json")
+	content := `{
+		"resources": {
+			"dbPassword": {
+				"type": "parameter.v0",
+				"inputs": {"value": {"secret": true}}
+			},
+			"api": {
+				"type": "container.v0",
+				"image": "myapp/api:latest",
+				"env": {"DB_PASSWORD": "{dbPassword}"}
+			}
+		}
+	}`
+	require.NoError(t, os.WriteFile(manifestPath, []byte(content), 0644))
+
+	outputDir := filepath.Join(tmpDir, "output")
+
+	r := &Runner{
+		Output:                &output.MockOutput{},
+		AspireManifestPath:    manifestPath,
+		AspireAppName:         "testapp",
+		AspireOutputDir:       outputDir,
+		AspireSecretBackend:   "radius-secretstore",
+		AspireSecretStoreName: "app-secrets",
+	}
+
+	err := r.runAspireTranslation(context.Background())
+	require.NoError(t, err)
+
+	bicepPath := filepath.Join(outputDir, "app.bicep")
+	data, err := os.ReadFile(bicepPath)
+	require.NoError(t, err)
+
+	bicep := string(data)
+	assert.Contains(t, bicep, "Applications.Core/secretStores")
+	assert.Contains(t, bicep, "app-secrets")
+}